@@ -108,7 +108,7 @@ func NewServer(opts ...Option) *Server {
 	}
 
 	if s.enableRequestID {
-		s.router.Use(middleware.RequestID)
+		s.router.Use(middleware.RequestID, logxRequestID)
 	}
 	if s.enableRealIP {
 		s.router.Use(middleware.RealIP)
@@ -123,6 +123,17 @@ func NewServer(opts ...Option) *Server {
 	return s
 }
 
+// logxRequestID copies the ID set by middleware.RequestID into the logx context
+// so it is attached to every log entry written with the request context.
+func logxRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := middleware.GetReqID(r.Context()); id != "" {
+			r = r.WithContext(logx.ContextWithRequestID(r.Context(), id))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func (s *Server) Router() *chi.Mux { return s.router }
 
 func (s *Server) HTTPServer() *http.Server {
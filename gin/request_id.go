@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/vixyninja/go-blocks/logx"
 )
 
 type ContextKey string
@@ -25,6 +26,7 @@ func RequestIDMiddleware() gin.HandlerFunc {
 		c.Set(string(RequestIDKey), requestID)
 
 		ctx := context.WithValue(c.Request.Context(), RequestIDKey, requestID)
+		ctx = logx.ContextWithRequestID(ctx, requestID)
 		c.Request = c.Request.WithContext(ctx)
 
 		c.Header(RequestIDHeader, requestID)
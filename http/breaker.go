@@ -0,0 +1,245 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned, wrapped with the host, when a request is
+// rejected by an open circuit breaker. No network call is made.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type CircuitState int
+
+const (
+	StateClosed CircuitState = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+type BreakerConfig struct {
+	FailureRate      float64       // Failure ratio in a window that opens the circuit (default: 0.5)
+	MinRequests      int           // Requests needed in a window before the rate is checked (default: 10)
+	Window           time.Duration // Period over which failures are counted (default: 1m)
+	CoolDown         time.Duration // Time the circuit stays open before probing (default: 30s)
+	HalfOpenRequests int           // Successful probes needed to close again (default: 1)
+
+	// IsFailure decides whether a round trip counts as a failure (default:
+	// a transport error or a 5xx status).
+	IsFailure func(resp *http.Response, err error) bool
+
+	// OnStateChange is called after the circuit of host changes state.
+	OnStateChange func(host string, from, to CircuitState)
+}
+
+func DefaultBreakerConfig() *BreakerConfig {
+	return &BreakerConfig{
+		FailureRate:      0.5,
+		MinRequests:      10,
+		Window:           time.Minute,
+		CoolDown:         30 * time.Second,
+		HalfOpenRequests: 1,
+	}
+}
+
+func (c BreakerConfig) withDefaults() BreakerConfig {
+	defaults := DefaultBreakerConfig()
+	if c.FailureRate <= 0 || c.FailureRate > 1 {
+		c.FailureRate = defaults.FailureRate
+	}
+	if c.MinRequests <= 0 {
+		c.MinRequests = defaults.MinRequests
+	}
+	if c.Window <= 0 {
+		c.Window = defaults.Window
+	}
+	if c.CoolDown <= 0 {
+		c.CoolDown = defaults.CoolDown
+	}
+	if c.HalfOpenRequests <= 0 {
+		c.HalfOpenRequests = defaults.HalfOpenRequests
+	}
+	if c.IsFailure == nil {
+		c.IsFailure = defaultIsFailure
+	}
+	return c
+}
+
+func defaultIsFailure(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// breakerTransport keeps one circuit per host.
+type breakerTransport struct {
+	next   http.RoundTripper
+	config BreakerConfig
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+func newBreakerTransport(next http.RoundTripper, config BreakerConfig) *breakerTransport {
+	return &breakerTransport{
+		next:     next,
+		config:   config.withDefaults(),
+		circuits: make(map[string]*circuit),
+	}
+}
+
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	c := t.circuit(host)
+
+	allowed, change := c.allow(time.Now())
+	t.notify(host, change)
+	if !allowed {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, fmt.Errorf("%w: %s", ErrCircuitOpen, host)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+
+	// A caller giving up says nothing about the health of the host.
+	if err != nil && req.Context().Err() != nil {
+		c.release()
+		return resp, err
+	}
+
+	t.notify(host, c.record(time.Now(), t.config.IsFailure(resp, err)))
+	return resp, err
+}
+
+func (t *breakerTransport) circuit(host string) *circuit {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c, ok := t.circuits[host]
+	if !ok {
+		c = &circuit{config: &t.config}
+		t.circuits[host] = c
+	}
+	return c
+}
+
+func (t *breakerTransport) notify(host string, change *stateChange) {
+	if change != nil && t.config.OnStateChange != nil {
+		t.config.OnStateChange(host, change.from, change.to)
+	}
+}
+
+type stateChange struct {
+	from, to CircuitState
+}
+
+type circuit struct {
+	config *BreakerConfig
+
+	mu          sync.Mutex
+	state       CircuitState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probes      int // half-open requests in flight
+	successes   int // successful half-open probes
+}
+
+// allow reports whether a request may go through, moving an open circuit to
+// half-open once the cool-down has passed.
+func (c *circuit) allow(now time.Time) (bool, *stateChange) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var change *stateChange
+	switch c.state {
+	case StateClosed:
+		if now.Sub(c.windowStart) >= c.config.Window {
+			c.windowStart, c.requests, c.failures = now, 0, 0
+		}
+		return true, nil
+
+	case StateOpen:
+		if now.Sub(c.openedAt) < c.config.CoolDown {
+			return false, nil
+		}
+		change = c.setState(StateHalfOpen, now)
+	}
+
+	if c.probes >= c.config.HalfOpenRequests {
+		return false, change
+	}
+	c.probes++
+	return true, change
+}
+
+func (c *circuit) record(now time.Time, failed bool) *stateChange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch c.state {
+	case StateClosed:
+		c.requests++
+		if failed {
+			c.failures++
+		}
+		if c.requests >= c.config.MinRequests &&
+			float64(c.failures)/float64(c.requests) >= c.config.FailureRate {
+			return c.setState(StateOpen, now)
+		}
+
+	case StateHalfOpen:
+		if c.probes > 0 {
+			c.probes--
+		}
+		if failed {
+			return c.setState(StateOpen, now)
+		}
+		c.successes++
+		if c.successes >= c.config.HalfOpenRequests {
+			return c.setState(StateClosed, now)
+		}
+	}
+	return nil
+}
+
+// release gives back a half-open probe slot without recording an outcome.
+func (c *circuit) release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state == StateHalfOpen && c.probes > 0 {
+		c.probes--
+	}
+}
+
+func (c *circuit) setState(state CircuitState, now time.Time) *stateChange {
+	change := &stateChange{from: c.state, to: state}
+	c.state = state
+	c.probes, c.successes = 0, 0
+
+	switch state {
+	case StateOpen:
+		c.openedAt = now
+	case StateClosed:
+		c.windowStart, c.requests, c.failures = now, 0, 0
+	}
+	return change
+}
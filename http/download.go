@@ -0,0 +1,226 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ProgressFunc reports the bytes downloaded so far, including any resumed
+// part, and the total size or -1 when the server does not send it.
+type ProgressFunc func(done, total int64)
+
+type DownloadOption func(*downloadOptions)
+
+type downloadOptions struct {
+	headers  map[string]string
+	progress ProgressFunc
+}
+
+func WithDownloadHeaders(headers map[string]string) DownloadOption {
+	return func(o *downloadOptions) {
+		for k, v := range headers {
+			o.headers[k] = v
+		}
+	}
+}
+
+func WithProgress(fn ProgressFunc) DownloadOption {
+	return func(o *downloadOptions) {
+		o.progress = fn
+	}
+}
+
+// Download streams the body of url into w and returns the number of bytes written.
+func Download(ctx context.Context, c HTTPClient, url string, w io.Writer, opts ...DownloadOption) (int64, error) {
+	o := newDownloadOptions(opts)
+
+	resp, err := c.NewRequest(http.MethodGet, url).Headers(o.headers).Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer CloseResponse(resp)
+
+	if !IsSuccess(resp) {
+		return 0, readStatusError(resp)
+	}
+	return copyWithProgress(w, resp.Body, 0, resp.ContentLength, o.progress)
+}
+
+// DownloadFile downloads url to path. When path already holds part of the
+// file it asks for the rest with a Range header and appends to it; servers
+// that ignore Range get the file rewritten from the start. It returns the
+// number of bytes written by this call.
+//
+// While a download is incomplete the ETag or Last-Modified of the file is
+// kept next to it in path+".validator" and sent as If-Range on resume, so a
+// file that changed on the server is downloaded again instead of appended.
+func DownloadFile(ctx context.Context, c HTTPClient, url, path string, opts ...DownloadOption) (int64, error) {
+	o := newDownloadOptions(opts)
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	offset := info.Size()
+	validatorPath := path + ".validator"
+
+	req := c.NewRequest(http.MethodGet, url).Headers(o.headers)
+	if offset > 0 {
+		req.Header(Range, "bytes="+strconv.FormatInt(offset, 10)+"-")
+		if validator, err := os.ReadFile(validatorPath); err == nil && len(validator) > 0 {
+			req.Header(IfRange, string(validator))
+		}
+	}
+
+	resp, err := req.Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer CloseResponse(resp)
+
+	switch {
+	case resp.StatusCode == http.StatusPartialContent:
+		start, total, ok := parseContentRange(resp.Header.Get(ContentRange))
+		if !ok || start != offset {
+			return 0, fmt.Errorf("unexpected Content-Range %q for a resume at %d", resp.Header.Get(ContentRange), offset)
+		}
+		if _, err := file.Seek(offset, io.SeekStart); err != nil {
+			return 0, fmt.Errorf("failed to seek %s: %w", path, err)
+		}
+		return finishDownload(file, resp.Body, offset, total, o.progress, validatorPath)
+
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		// Nothing left to fetch when the file already has the full size.
+		if _, total, ok := parseContentRange(resp.Header.Get(ContentRange)); ok && total == offset {
+			os.Remove(validatorPath)
+			return 0, nil
+		}
+		return 0, readStatusError(resp)
+
+	case IsSuccess(resp):
+		if err := file.Truncate(0); err != nil {
+			return 0, fmt.Errorf("failed to truncate %s: %w", path, err)
+		}
+		if err := saveValidator(validatorPath, resp); err != nil {
+			return 0, err
+		}
+		return finishDownload(file, resp.Body, 0, resp.ContentLength, o.progress, validatorPath)
+
+	default:
+		return 0, readStatusError(resp)
+	}
+}
+
+// saveValidator stores what If-Range accepts for resp: a strong ETag, or
+// else Last-Modified. Without either any stale validator is removed.
+func saveValidator(path string, resp *http.Response) error {
+	validator := resp.Header.Get(ETag)
+	if validator == "" || strings.HasPrefix(validator, "W/") {
+		validator = resp.Header.Get(LastModified)
+	}
+	if validator == "" {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(validator), 0o644); err != nil {
+		return fmt.Errorf("failed to save %s: %w", path, err)
+	}
+	return nil
+}
+
+// finishDownload copies the body and drops the validator once the file is complete.
+func finishDownload(w io.Writer, r io.Reader, offset, total int64, progress ProgressFunc, validatorPath string) (int64, error) {
+	n, err := copyWithProgress(w, r, offset, total, progress)
+	if err == nil {
+		os.Remove(validatorPath)
+	}
+	return n, err
+}
+
+func newDownloadOptions(opts []DownloadOption) *downloadOptions {
+	o := &downloadOptions{headers: make(map[string]string)}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func readStatusError(resp *http.Response) error {
+	body, _ := readBody(resp.Body, maxErrorBodySize)
+	return newStatusError(resp, body)
+}
+
+func copyWithProgress(w io.Writer, r io.Reader, offset, total int64, progress ProgressFunc) (int64, error) {
+	if progress != nil {
+		w = &progressWriter{w: w, done: offset, total: total, fn: progress}
+	}
+
+	n, err := io.Copy(w, r)
+	if err != nil {
+		return n, fmt.Errorf("failed to download: %w", err)
+	}
+	return n, nil
+}
+
+type progressWriter struct {
+	w     io.Writer
+	done  int64
+	total int64
+	fn    ProgressFunc
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.done += int64(n)
+	p.fn(p.done, p.total)
+	return n, err
+}
+
+// parseContentRange parses "bytes start-end/total" and "bytes */total". A
+// total of "*" is returned as -1.
+func parseContentRange(value string) (start, total int64, ok bool) {
+	rest, found := strings.CutPrefix(value, "bytes ")
+	if !found {
+		return 0, 0, false
+	}
+	span, size, found := strings.Cut(rest, "/")
+	if !found {
+		return 0, 0, false
+	}
+
+	total = -1
+	if size != "*" {
+		n, err := strconv.ParseInt(size, 10, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+		total = n
+	}
+
+	if span == "*" {
+		return 0, total, true
+	}
+	first, _, found := strings.Cut(span, "-")
+	if !found {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, total, true
+}
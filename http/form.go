@@ -0,0 +1,114 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+)
+
+// FormRequest encodes values as an application/x-www-form-urlencoded body, see FormHeaders.
+func FormRequest(values url.Values) io.Reader {
+	return strings.NewReader(values.Encode())
+}
+
+// Multipart builds a multipart/form-data body that is streamed as it is
+// sent, so files are never held in memory. Streamed bodies cannot be
+// replayed, so requests carrying one are not retried.
+type Multipart struct {
+	parts []multipartPart
+}
+
+type multipartPart struct {
+	field       string
+	value       string
+	filename    string
+	contentType string
+	reader      io.Reader
+}
+
+func NewMultipart() *Multipart {
+	return &Multipart{}
+}
+
+func (m *Multipart) Field(name, value string) *Multipart {
+	m.parts = append(m.parts, multipartPart{field: name, value: value})
+	return m
+}
+
+// File adds a file part read from r when the request is sent. If r is an
+// io.Closer it is closed once copied.
+func (m *Multipart) File(field, filename string, r io.Reader) *Multipart {
+	return m.FileWithType(field, filename, "application/octet-stream", r)
+}
+
+func (m *Multipart) FileWithType(field, filename, contentType string, r io.Reader) *Multipart {
+	m.parts = append(m.parts, multipartPart{field: field, filename: filename, contentType: contentType, reader: r})
+	return m
+}
+
+// Reader returns the body and its Content-Type. The body is produced by a
+// goroutine writing into a pipe, it must be read to the end or closed.
+func (m *Multipart) Reader() (io.ReadCloser, string) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		pw.CloseWithError(m.write(mw))
+	}()
+
+	return streamingBody{pr}, mw.FormDataContentType()
+}
+
+func (m *Multipart) write(mw *multipart.Writer) error {
+	for _, part := range m.parts {
+		if part.reader == nil {
+			if err := mw.WriteField(part.field, part.value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", multipart.FileContentDisposition(part.field, part.filename))
+		header.Set(ContentType, part.contentType)
+
+		w, err := mw.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, part.reader)
+		if closer, ok := part.reader.(io.Closer); ok {
+			closer.Close()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to stream %q: %w", part.filename, err)
+		}
+	}
+	return mw.Close()
+}
+
+// StreamBody marks r as a body that is sent as it is read, such as a large
+// *os.File. Other bodies without GetBody are read into memory whenever Retry
+// or a refreshing TokenSource may need to send them again; streamed ones are
+// sent once, so those requests are neither retried nor retried after a 401.
+// If r is an io.Closer it is closed once sent.
+func StreamBody(r io.Reader) io.ReadCloser {
+	if rc, ok := r.(io.ReadCloser); ok {
+		return streamingBody{rc}
+	}
+	return streamingBody{io.NopCloser(r)}
+}
+
+// streamingBody marks a body that must not be buffered for replay.
+type streamingBody struct {
+	io.ReadCloser
+}
+
+func isStreaming(req *http.Request) bool {
+	_, ok := req.Body.(streamingBody)
+	return ok
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
 )
 
 type httpClient struct {
@@ -12,71 +13,118 @@ type httpClient struct {
 	config *Config
 }
 
+// NewHTTPClient builds a client from config. If the transport cannot be
+// built, for example because a PEM file is missing, every request returns
+// that error; use BuildHTTPClient to handle it up front.
 func NewHTTPClient(config *Config) HTTPClient {
+	client, err := BuildHTTPClient(config)
+	if err != nil {
+		return newClient(config, RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			closeRequestBody(req)
+			return nil, err
+		}))
+	}
+	return client
+}
+
+// BuildHTTPClient is like NewHTTPClient but returns an error when the transport cannot be built.
+func BuildHTTPClient(config *Config) (HTTPClient, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	transportConfig := config.Transport
+	if transportConfig == nil {
+		transportConfig = DefaultTransportConfig()
+	}
+	transport, err := NewTransport(*transportConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transport: %w", err)
+	}
+
+	return newClient(config, transport), nil
+}
+
+func newClient(config *Config, base http.RoundTripper) *httpClient {
 	if config == nil {
 		config = DefaultConfig()
 	}
 
-	client := &http.Client{
-		Timeout: config.Timeout,
+	transport := base
+	if config.Retry != nil {
+		transport = newRetryTransport(transport, *config.Retry)
+	}
+	// The breaker sits outside retries so an open circuit fails fast and a
+	// call that exhausted its retries counts as one failure.
+	if config.Breaker != nil {
+		transport = newBreakerTransport(transport, *config.Breaker)
 	}
+	if config.TokenSource != nil {
+		transport = BearerAuth(config.TokenSource)(transport)
+	}
+	transport = chainMiddleware(transport, config.Middleware)
 
 	return &httpClient{
-		client: client,
+		client: &http.Client{
+			Timeout:   config.Timeout,
+			Transport: transport,
+		},
 		config: config,
 	}
 }
 
-func (c *httpClient) Get(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create GET request: %w", err)
+func (c *httpClient) NewRequest(method, url string) *RequestBuilder {
+	return &RequestBuilder{
+		client: c,
+		method: method,
+		rawURL: url,
+		query:  make(neturl.Values),
+		header: make(http.Header),
 	}
+}
 
-	c.setHeaders(req, headers)
+// Do sends req after filling in the default headers and User-Agent it does not set.
+func (c *httpClient) Do(req *http.Request) (*http.Response, error) {
+	c.setDefaultHeaders(req)
 	return c.client.Do(req)
 }
 
-func (c *httpClient) Post(ctx context.Context, url string, body io.Reader, headers map[string]string) (*http.Response, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create POST request: %w", err)
-	}
+func (c *httpClient) Get(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
+	return c.NewRequest(http.MethodGet, url).Headers(headers).Do(ctx)
+}
 
-	c.setHeaders(req, headers)
-	return c.client.Do(req)
+func (c *httpClient) Head(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
+	return c.NewRequest(http.MethodHead, url).Headers(headers).Do(ctx)
+}
+
+func (c *httpClient) Options(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
+	return c.NewRequest(http.MethodOptions, url).Headers(headers).Do(ctx)
+}
+
+func (c *httpClient) Post(ctx context.Context, url string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	return c.NewRequest(http.MethodPost, url).Body(body).Headers(headers).Do(ctx)
 }
 
 func (c *httpClient) Put(ctx context.Context, url string, body io.Reader, headers map[string]string) (*http.Response, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create PUT request: %w", err)
-	}
+	return c.NewRequest(http.MethodPut, url).Body(body).Headers(headers).Do(ctx)
+}
 
-	c.setHeaders(req, headers)
-	return c.client.Do(req)
+func (c *httpClient) Patch(ctx context.Context, url string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	return c.NewRequest(http.MethodPatch, url).Body(body).Headers(headers).Do(ctx)
 }
 
 func (c *httpClient) Delete(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create DELETE request: %w", err)
-	}
-
-	c.setHeaders(req, headers)
-	return c.client.Do(req)
+	return c.NewRequest(http.MethodDelete, url).Headers(headers).Do(ctx)
 }
 
-func (c *httpClient) setHeaders(req *http.Request, customHeaders map[string]string) {
+func (c *httpClient) setDefaultHeaders(req *http.Request) {
 	for key, value := range c.config.DefaultHeaders {
-		req.Header.Set(key, value)
-	}
-
-	if req.Header.Get("User-Agent") == "" && c.config.UserAgent != "" {
-		req.Header.Set("User-Agent", c.config.UserAgent)
+		if _, ok := req.Header[http.CanonicalHeaderKey(key)]; !ok {
+			req.Header.Set(key, value)
+		}
 	}
 
-	for key, value := range customHeaders {
-		req.Header.Set(key, value)
+	if req.Header.Get(UserAgent) == "" && c.config.UserAgent != "" {
+		req.Header.Set(UserAgent, c.config.UserAgent)
 	}
 }
@@ -0,0 +1,211 @@
+package httpmock
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	httpClient "github.com/vixyninja/go-blocks/http"
+)
+
+type Mode int
+
+const (
+	// ModeReplay answers from the golden file and fails on unknown requests.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the real transport and saves every exchange.
+	ModeRecord
+)
+
+// ErrNoInteraction is returned in replay mode for requests not in the golden file.
+var ErrNoInteraction = errors.New("httpmock: no recorded interaction")
+
+// Interaction is one request and response pair stored in a golden file.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   []byte      `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       []byte      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records real exchanges to a golden
+// file or replays them offline. Requests are matched on method, URL and
+// body; identical requests replay their recorded responses in order.
+// Authorization and cookie headers are redacted before saving. Bodies are
+// stored as base64 so binary exchanges replay byte for byte.
+//
+// A common setup records when an environment variable is set:
+//
+//	mode := httpmock.ModeReplay
+//	if os.Getenv("HTTPMOCK_RECORD") != "" {
+//		mode = httpmock.ModeRecord
+//	}
+type Recorder struct {
+	path string
+	mode Mode
+	next http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     map[int]bool
+}
+
+// NewRecorder opens the golden file at path. In ModeReplay the file must
+// exist; in ModeRecord it is overwritten and requests go to next, or to
+// http.DefaultTransport when next is nil.
+func NewRecorder(path string, mode Mode, next http.RoundTripper) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	r := &Recorder{path: path, mode: mode, next: next, replayed: make(map[int]bool)}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("httpmock: failed to read golden file: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.interactions); err != nil {
+			return nil, fmt.Errorf("httpmock: failed to parse golden file: %w", err)
+		}
+	}
+	return r, nil
+}
+
+// Middleware plugs the recorder into http.Config.Middleware. In replay mode
+// the rest of the transport chain is never called.
+func (r *Recorder) Middleware() httpClient.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		if r.mode == ModeRecord {
+			r.next = next
+		}
+		return r
+	}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+	for i, in := range r.interactions {
+		if in.Request.Method != req.Method || in.Request.URL != req.URL.String() || !bytes.Equal(in.Request.Body, body) {
+			continue
+		}
+		match = i
+		if !r.replayed[i] {
+			break
+		}
+	}
+	if match == -1 {
+		return nil, fmt.Errorf("%w for %s %s", ErrNoInteraction, req.Method, req.URL)
+	}
+	r.replayed[match] = true
+
+	recorded := r.interactions[match].Response
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("httpmock: failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.interactions = append(r.interactions, Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: httpClient.RedactHeaders(req.Header),
+			Body:   body,
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     httpClient.RedactHeaders(resp.Header),
+			Body:       respBody,
+		},
+	})
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// save writes all interactions so far, so a failing test still leaves a usable file.
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("httpmock: failed to encode golden file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("httpmock: failed to create golden file directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("httpmock: failed to write golden file: %w", err)
+	}
+	return nil
+}
+
+// readRequestBody reads the body of req and puts a fresh copy back.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("httpmock: failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
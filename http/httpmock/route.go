@@ -0,0 +1,248 @@
+package httpmock
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Fault makes a response fail at the connection level instead of answering.
+type Fault int
+
+const (
+	// FaultConnectionReset aborts the connection with a TCP reset.
+	FaultConnectionReset Fault = iota + 1
+	// FaultEmptyResponse closes the connection without writing anything.
+	FaultEmptyResponse
+)
+
+// Route matches requests and answers them. Responses added with the Reply
+// methods are used in order, the last one repeats once the others are used.
+// ReplyHeader, Delay and Fault change the most recently added response.
+type Route struct {
+	method   string
+	path     string
+	query    url.Values
+	header   http.Header
+	jsonBody any
+	hasJSON  bool
+	matchers []func(*Request) bool
+	times    int
+
+	mu        sync.Mutex
+	calls     int
+	responses []*response
+}
+
+type response struct {
+	status int
+	header http.Header
+	body   []byte
+	delay  time.Duration
+	fault  Fault
+}
+
+// Query requires the query parameter key to have value.
+func (r *Route) Query(key, value string) *Route {
+	if r.query == nil {
+		r.query = url.Values{}
+	}
+	r.query.Add(key, value)
+	return r
+}
+
+// Header requires the request header key to have value.
+func (r *Route) Header(key, value string) *Route {
+	if r.header == nil {
+		r.header = http.Header{}
+	}
+	r.header.Add(key, value)
+	return r
+}
+
+// JSONBody requires the request body to be JSON equal to v, ignoring formatting and key order.
+func (r *Route) JSONBody(v any) *Route {
+	r.jsonBody = normalizeJSON(v)
+	r.hasJSON = true
+	return r
+}
+
+// Match adds a custom matcher.
+func (r *Route) Match(fn func(*Request) bool) *Route {
+	r.matchers = append(r.matchers, fn)
+	return r
+}
+
+// Times expects exactly n calls; after n the route stops matching.
+func (r *Route) Times(n int) *Route {
+	r.times = n
+	return r
+}
+
+func (r *Route) Once() *Route {
+	return r.Times(1)
+}
+
+func (r *Route) Reply(status int) *Route {
+	r.responses = append(r.responses, &response{status: status, header: http.Header{}})
+	return r
+}
+
+func (r *Route) ReplyString(status int, body string) *Route {
+	r.Reply(status)
+	r.last().body = []byte(body)
+	return r
+}
+
+// ReplyJSON answers with v encoded as JSON. It panics if v cannot be encoded.
+func (r *Route) ReplyJSON(status int, v any) *Route {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic("httpmock: " + err.Error())
+	}
+	r.Reply(status)
+	last := r.last()
+	last.header.Set("Content-Type", "application/json")
+	last.body = data
+	return r
+}
+
+// ReplyHeader sets a header on the last response.
+func (r *Route) ReplyHeader(key, value string) *Route {
+	r.last().header.Set(key, value)
+	return r
+}
+
+// Delay holds the last response for d, or until the client gives up.
+func (r *Route) Delay(d time.Duration) *Route {
+	r.last().delay = d
+	return r
+}
+
+// Fault replaces the last response with a connection failure.
+func (r *Route) Fault(f Fault) *Route {
+	r.last().fault = f
+	return r
+}
+
+// Calls returns how many requests the route answered.
+func (r *Route) Calls() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.calls
+}
+
+// last returns the most recent response, adding a 200 if there is none yet.
+func (r *Route) last() *response {
+	if len(r.responses) == 0 {
+		r.Reply(http.StatusOK)
+	}
+	return r.responses[len(r.responses)-1]
+}
+
+func (r *Route) matches(req *Request) bool {
+	if r.method != "" && r.method != req.Method {
+		return false
+	}
+	if r.path != "" && r.path != req.Path {
+		return false
+	}
+	for key, values := range r.query {
+		for _, v := range values {
+			if !slices.Contains(req.Query[key], v) {
+				return false
+			}
+		}
+	}
+	for key, values := range r.header {
+		for _, v := range values {
+			if !slices.Contains(req.Header.Values(key), v) {
+				return false
+			}
+		}
+	}
+	if r.hasJSON {
+		var body any
+		if json.Unmarshal(req.Body, &body) != nil || !reflect.DeepEqual(body, r.jsonBody) {
+			return false
+		}
+	}
+	for _, match := range r.matchers {
+		if !match(req) {
+			return false
+		}
+	}
+	return true
+}
+
+// take counts a call and returns the response for it, or false when req does
+// not match or the route has had all its calls.
+func (r *Route) take(req *Request) (*response, bool) {
+	if !r.matches(req) {
+		return nil, false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.times > 0 && r.calls >= r.times {
+		return nil, false
+	}
+	r.calls++
+
+	if len(r.responses) == 0 {
+		return &response{status: http.StatusOK}, true
+	}
+	return r.responses[min(r.calls, len(r.responses))-1], true
+}
+
+func (resp *response) write(w http.ResponseWriter, req *http.Request) {
+	if resp.delay > 0 {
+		select {
+		case <-time.After(resp.delay):
+		case <-req.Context().Done():
+			return
+		}
+	}
+
+	if resp.fault != 0 {
+		fail(w, resp.fault)
+		return
+	}
+
+	for key, values := range resp.header {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(resp.status)
+	_, _ = w.Write(resp.body)
+}
+
+func fail(w http.ResponseWriter, fault Fault) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		panic("httpmock: response writer does not support hijacking")
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+	if tcp, ok := conn.(*net.TCPConn); ok && fault == FaultConnectionReset {
+		_ = tcp.SetLinger(0)
+	}
+	_ = conn.Close()
+}
+
+func normalizeJSON(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic("httpmock: " + err.Error())
+	}
+	var out any
+	_ = json.Unmarshal(data, &out)
+	return out
+}
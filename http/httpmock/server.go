@@ -0,0 +1,140 @@
+// Package httpmock provides a mock server with route matchers and a
+// record/replay transport for testing code built on the http package.
+package httpmock
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Request is a request received by the Server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+func (r *Request) String() string {
+	if len(r.Query) == 0 {
+		return r.Method + " " + r.Path
+	}
+	return r.Method + " " + r.Path + "?" + r.Query.Encode()
+}
+
+// Server is an httptest.Server that answers from registered routes. Routes
+// are matched in registration order; requests matching no route get a 404
+// and fail AssertExpectations.
+type Server struct {
+	URL string
+
+	server *httptest.Server
+
+	mu        sync.Mutex
+	routes    []*Route
+	requests  []*Request
+	unmatched []*Request
+}
+
+// NewServer starts a server that is closed when t finishes.
+func NewServer(t testing.TB) *Server {
+	s := &Server{}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// On registers a route for method and path. An empty method or path matches any.
+func (s *Server) On(method, path string) *Route {
+	route := &Route{method: method, path: path}
+
+	s.mu.Lock()
+	s.routes = append(s.routes, route)
+	s.mu.Unlock()
+	return route
+}
+
+// Requests returns every request received, matched or not, in order.
+func (s *Server) Requests() []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Request(nil), s.requests...)
+}
+
+// AssertExpectations fails t for routes called a different number of times
+// than set with Times, routes never called and requests that matched nothing.
+func (s *Server) AssertExpectations(t testing.TB) {
+	t.Helper()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, route := range s.routes {
+		calls := route.Calls()
+		switch {
+		case route.times > 0 && calls != route.times:
+			t.Errorf("httpmock: expected %s to be called %d times, got %d", route, route.times, calls)
+		case route.times == 0 && calls == 0:
+			t.Errorf("httpmock: expected %s to be called", route)
+		}
+	}
+	for _, req := range s.unmatched {
+		t.Errorf("httpmock: no route matched %s", req)
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	req := &Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	var resp *response
+	for _, route := range s.routes {
+		if matched, ok := route.take(req); ok {
+			resp = matched
+			break
+		}
+	}
+	if resp == nil {
+		s.unmatched = append(s.unmatched, req)
+	}
+	s.mu.Unlock()
+
+	if resp == nil {
+		http.Error(w, fmt.Sprintf("httpmock: no route for %s", req), http.StatusNotFound)
+		return
+	}
+	resp.write(w, r)
+}
+
+func (r *Route) String() string {
+	var b strings.Builder
+	b.WriteString(cmp.Or(r.method, "*"))
+	b.WriteString(" ")
+	b.WriteString(cmp.Or(r.path, "*"))
+	if len(r.query) > 0 {
+		b.WriteString("?")
+		b.WriteString(r.query.Encode())
+	}
+	return b.String()
+}
@@ -9,15 +9,30 @@ import (
 
 type HTTPClient interface {
 	Get(ctx context.Context, url string, headers map[string]string) (*http.Response, error)
+	Head(ctx context.Context, url string, headers map[string]string) (*http.Response, error)
+	Options(ctx context.Context, url string, headers map[string]string) (*http.Response, error)
 	Post(ctx context.Context, url string, body io.Reader, headers map[string]string) (*http.Response, error)
 	Put(ctx context.Context, url string, body io.Reader, headers map[string]string) (*http.Response, error)
+	Patch(ctx context.Context, url string, body io.Reader, headers map[string]string) (*http.Response, error)
 	Delete(ctx context.Context, url string, headers map[string]string) (*http.Response, error)
+
+	// NewRequest starts a request with query parameters, multi-value
+	// headers, a JSON body or its own timeout.
+	NewRequest(method, url string) *RequestBuilder
+
+	// Do sends a prepared request with the client's defaults and transport.
+	Do(req *http.Request) (*http.Response, error)
 }
 
 type Config struct {
 	Timeout        time.Duration
 	UserAgent      string
 	DefaultHeaders map[string]string
+	Retry          *RetryConfig     // retry transient failures, nil disables retries
+	Breaker        *BreakerConfig   // per-host circuit breaker, nil disables it
+	Middleware     []Middleware     // wrap the transport, the first one is outermost
+	TokenSource    TokenSource      // sets Authorization on every request, see BearerAuth
+	Transport      *TransportConfig // TLS, proxy and pooling, nil uses DefaultTransportConfig
 }
 
 func DefaultConfig() *Config {
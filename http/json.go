@@ -0,0 +1,195 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/vixyninja/go-blocks/response"
+)
+
+const (
+	// DefaultMaxBodySize bounds the response bodies read by the JSON helpers.
+	DefaultMaxBodySize int64 = 10 << 20
+
+	// maxErrorBodySize bounds the body kept in a StatusError.
+	maxErrorBodySize = 4 << 10
+)
+
+// ErrBodyTooLarge is returned when a response body exceeds the configured maximum.
+var ErrBodyTooLarge = errors.New("response body too large")
+
+// StatusError is returned by the JSON helpers for non-2xx responses.
+type StatusError struct {
+	StatusCode int
+	Status     string
+	Header     http.Header
+	Body       []byte // first 4KB of the body
+
+	// Response is set when the body is a response.ErrorResponse, as written
+	// by the response package.
+	Response *response.ErrorResponse
+}
+
+func (e *StatusError) Error() string {
+	if e.Response != nil {
+		return fmt.Sprintf("unexpected status %d: %s: %s", e.StatusCode, e.Response.Code, e.Response.Message)
+	}
+	if len(e.Body) > 0 {
+		return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, strings.TrimSpace(string(e.Body)))
+	}
+	return fmt.Sprintf("unexpected status %d", e.StatusCode)
+}
+
+type JSONOption func(*jsonOptions)
+
+type jsonOptions struct {
+	headers     map[string]string
+	maxBodySize int64
+}
+
+// WithHeaders adds headers to the request, on top of the JSON headers.
+func WithHeaders(headers map[string]string) JSONOption {
+	return func(o *jsonOptions) {
+		for k, v := range headers {
+			o.headers[k] = v
+		}
+	}
+}
+
+func WithHeader(key, value string) JSONOption {
+	return func(o *jsonOptions) {
+		o.headers[key] = value
+	}
+}
+
+// WithMaxBodySize overrides DefaultMaxBodySize.
+func WithMaxBodySize(n int64) JSONOption {
+	return func(o *jsonOptions) {
+		o.maxBodySize = n
+	}
+}
+
+func newJSONOptions(opts []JSONOption) *jsonOptions {
+	o := &jsonOptions{
+		headers:     JSONHeaders(),
+		maxBodySize: DefaultMaxBodySize,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// GetJSON sends a GET request and decodes the JSON response into T.
+func GetJSON[T any](ctx context.Context, c HTTPClient, url string, opts ...JSONOption) (T, error) {
+	o := newJSONOptions(opts)
+	resp, err := c.Get(ctx, url, o.headers)
+	return decodeJSON[T](resp, err, o)
+}
+
+// PostJSON encodes body as JSON, sends it with POST and decodes the response into Resp.
+func PostJSON[Req, Resp any](ctx context.Context, c HTTPClient, url string, body Req, opts ...JSONOption) (Resp, error) {
+	o := newJSONOptions(opts)
+	reader, err := JSONRequest(body)
+	if err != nil {
+		var zero Resp
+		return zero, err
+	}
+	resp, err := c.Post(ctx, url, reader, o.headers)
+	return decodeJSON[Resp](resp, err, o)
+}
+
+// PutJSON encodes body as JSON, sends it with PUT and decodes the response into Resp.
+func PutJSON[Req, Resp any](ctx context.Context, c HTTPClient, url string, body Req, opts ...JSONOption) (Resp, error) {
+	o := newJSONOptions(opts)
+	reader, err := JSONRequest(body)
+	if err != nil {
+		var zero Resp
+		return zero, err
+	}
+	resp, err := c.Put(ctx, url, reader, o.headers)
+	return decodeJSON[Resp](resp, err, o)
+}
+
+// PatchJSON encodes body as JSON, sends it with PATCH and decodes the response into Resp.
+func PatchJSON[Req, Resp any](ctx context.Context, c HTTPClient, url string, body Req, opts ...JSONOption) (Resp, error) {
+	o := newJSONOptions(opts)
+	reader, err := JSONRequest(body)
+	if err != nil {
+		var zero Resp
+		return zero, err
+	}
+	resp, err := c.Patch(ctx, url, reader, o.headers)
+	return decodeJSON[Resp](resp, err, o)
+}
+
+// DeleteJSON sends a DELETE request and decodes the JSON response into T.
+func DeleteJSON[T any](ctx context.Context, c HTTPClient, url string, opts ...JSONOption) (T, error) {
+	o := newJSONOptions(opts)
+	resp, err := c.Delete(ctx, url, o.headers)
+	return decodeJSON[T](resp, err, o)
+}
+
+// decodeJSON always closes the body. A StatusError wins over a read error so
+// an oversized error page still reports its status, and empty bodies such as
+// 204 responses yield the zero value of T.
+func decodeJSON[T any](resp *http.Response, err error, o *jsonOptions) (T, error) {
+	var out T
+	if err != nil {
+		return out, err
+	}
+	defer CloseResponse(resp)
+
+	body, err := readBody(resp.Body, o.maxBodySize)
+	if !IsSuccess(resp) {
+		return out, newStatusError(resp, body)
+	}
+	if err != nil {
+		return out, err
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return out, nil
+	}
+	if err := json.Unmarshal(body, &out); err != nil {
+		return out, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+	return out, nil
+}
+
+// readBody returns at most limit bytes. The bytes read so far are returned
+// with the error when the body is larger.
+func readBody(r io.Reader, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		limit = DefaultMaxBodySize
+	}
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return body, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if int64(len(body)) > limit {
+		return body[:limit], fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, limit)
+	}
+	return body, nil
+}
+
+func newStatusError(resp *http.Response, body []byte) *StatusError {
+	statusErr := &StatusError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Header:     resp.Header,
+		Body:       body[:min(len(body), maxErrorBodySize)],
+	}
+
+	var errResp response.ErrorResponse
+	if json.Unmarshal(body, &errResp) == nil && errResp.Code != "" {
+		statusErr.Response = &errResp
+	}
+	return statusErr
+}
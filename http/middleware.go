@@ -0,0 +1,111 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/vixyninja/go-blocks/logx"
+)
+
+// Middleware wraps the transport of the client. Middlewares in
+// Config.Middleware run in order around retries and the circuit breaker, so
+// they see each call once.
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func chainMiddleware(transport http.RoundTripper, middleware []Middleware) http.RoundTripper {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		transport = middleware[i](transport)
+	}
+	return transport
+}
+
+// RequestID sets X-Request-Id from logx.RequestIDFromContext when the
+// request does not carry one yet.
+func RequestID() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(XRequestID) == "" {
+				if id := logx.RequestIDFromContext(req.Context()); id != "" {
+					req = req.Clone(req.Context())
+					req.Header.Set(XRequestID, id)
+				}
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// DefaultRedactedHeaders are masked by RedactHeaders when no names are given.
+var DefaultRedactedHeaders = []string{Authorization, ProxyAuthorization, "Cookie", "Set-Cookie"}
+
+const redactedHeaderValue = "[REDACTED]"
+
+// RedactHeaders returns a copy of h with the values of names masked, for
+// logging or metrics. It never changes the request that is sent.
+func RedactHeaders(h http.Header, names ...string) http.Header {
+	if len(names) == 0 {
+		names = DefaultRedactedHeaders
+	}
+
+	out := h.Clone()
+	for _, name := range names {
+		if values := out.Values(name); len(values) > 0 {
+			masked := make([]string, len(values))
+			for i := range masked {
+				masked[i] = redactedHeaderValue
+			}
+			out[http.CanonicalHeaderKey(name)] = masked
+		}
+	}
+	return out
+}
+
+type LoggingConfig struct {
+	Headers       bool     // also log request and response headers
+	RedactHeaders []string // headers masked in the log (default: DefaultRedactedHeaders)
+}
+
+// Logging logs every call with its method, URL, status and latency. Transport
+// errors are logged at error level, 5xx responses at warn and the rest at info.
+func Logging(logger logx.Logx, config LoggingConfig) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+
+			ctx := req.Context()
+			fields := []logx.Field{
+				logx.String("method", req.Method),
+				logx.String("url", req.URL.Redacted()),
+				logx.Duration("latency", time.Since(start)),
+			}
+			if config.Headers {
+				fields = append(fields, logx.Any("request_headers", RedactHeaders(req.Header, config.RedactHeaders...)))
+			}
+
+			if err != nil {
+				logger.Errorw(ctx, "http request failed", append(fields, logx.Err(err))...)
+				return resp, err
+			}
+
+			fields = append(fields, logx.Int("status", resp.StatusCode))
+			if config.Headers {
+				fields = append(fields, logx.Any("response_headers", RedactHeaders(resp.Header, config.RedactHeaders...)))
+			}
+
+			if resp.StatusCode >= http.StatusInternalServerError {
+				logger.Warnw(ctx, "http request", fields...)
+			} else {
+				logger.Infow(ctx, "http request", fields...)
+			}
+			return resp, err
+		})
+	}
+}
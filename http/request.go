@@ -0,0 +1,170 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RequestBuilder prepares a single request. Create one with
+// HTTPClient.NewRequest and finish it with Do or Build.
+type RequestBuilder struct {
+	client  *httpClient
+	method  string
+	rawURL  string
+	query   url.Values
+	header  http.Header
+	body    io.Reader
+	timeout time.Duration
+	err     error
+}
+
+// Query adds values for key to the query string, keeping any already in the URL.
+func (b *RequestBuilder) Query(key string, values ...string) *RequestBuilder {
+	for _, v := range values {
+		b.query.Add(key, v)
+	}
+	return b
+}
+
+// Header adds values for key, so repeated calls produce a multi-value header.
+func (b *RequestBuilder) Header(key string, values ...string) *RequestBuilder {
+	for _, v := range values {
+		b.header.Add(key, v)
+	}
+	return b
+}
+
+// Headers sets each header in headers, replacing previous values.
+func (b *RequestBuilder) Headers(headers map[string]string) *RequestBuilder {
+	for k, v := range headers {
+		b.header.Set(k, v)
+	}
+	return b
+}
+
+// Body sets the request body. Wrap large bodies with StreamBody so they are
+// not read into memory for retries.
+func (b *RequestBuilder) Body(body io.Reader) *RequestBuilder {
+	b.body = body
+	return b
+}
+
+// JSON encodes v as the request body and sets the JSON Content-Type and Accept headers.
+func (b *RequestBuilder) JSON(v any) *RequestBuilder {
+	data, err := json.Marshal(v)
+	if err != nil {
+		b.err = fmt.Errorf("failed to marshal JSON: %w", err)
+		return b
+	}
+
+	b.body = bytes.NewReader(data)
+	b.header.Set(ContentType, "application/json")
+	if b.header.Get(Accept) == "" {
+		b.header.Set(Accept, "application/json")
+	}
+	return b
+}
+
+// Form sets values as a url-encoded body.
+func (b *RequestBuilder) Form(values url.Values) *RequestBuilder {
+	b.body = FormRequest(values)
+	b.header.Set(ContentType, "application/x-www-form-urlencoded")
+	return b
+}
+
+// Multipart streams m as a multipart/form-data body. The parts are read
+// once the request is sent.
+func (b *RequestBuilder) Multipart(m *Multipart) *RequestBuilder {
+	body, contentType := m.Reader()
+	b.body = body
+	b.header.Set(ContentType, contentType)
+	return b
+}
+
+// Timeout bounds this request, including reading the response body.
+func (b *RequestBuilder) Timeout(d time.Duration) *RequestBuilder {
+	b.timeout = d
+	return b
+}
+
+// Build returns the *http.Request without sending it. Client defaults are
+// applied by Do.
+func (b *RequestBuilder) Build(ctx context.Context) (*http.Request, error) {
+	if b.err != nil {
+		b.closeBody()
+		return nil, b.err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, b.method, b.rawURL, b.body)
+	if err != nil {
+		b.closeBody()
+		return nil, fmt.Errorf("failed to create %s request: %w", b.method, err)
+	}
+
+	if len(b.query) > 0 {
+		q := req.URL.Query()
+		for k, values := range b.query {
+			for _, v := range values {
+				q.Add(k, v)
+			}
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	for k, values := range b.header {
+		req.Header[k] = append([]string(nil), values...)
+	}
+	return req, nil
+}
+
+func (b *RequestBuilder) Do(ctx context.Context) (*http.Response, error) {
+	if b.timeout <= 0 {
+		req, err := b.Build(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return b.client.Do(req)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, b.timeout)
+	req, err := b.Build(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// closeBody stops a streaming body that will never be sent.
+func (b *RequestBuilder) closeBody() {
+	if closer, ok := b.body.(io.Closer); ok {
+		closer.Close()
+	}
+}
+
+// cancelOnClose releases a per-request timeout once the body is closed
+// instead of when Do returns, so the body can still be read.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
@@ -0,0 +1,225 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+type RetryConfig struct {
+	MaxAttempts          int           // Total attempts including the first one (default: 3)
+	BackoffMin           time.Duration // Wait before the first retry (default: 100ms)
+	BackoffMax           time.Duration // Upper bound for the exponential backoff (default: 5s)
+	Jitter               float64       // Fraction of each wait that is randomized, 0 to 1
+	RetryableStatusCodes []int         // Status codes worth retrying (default: 429, 502, 503, 504)
+	RetryNonIdempotent   bool          // Also retry POST, PATCH and other non-idempotent requests
+	MaxRetryAfter        time.Duration // Longest Retry-After honoured, longer ones return the response (default: 30s)
+}
+
+func DefaultRetryConfig() *RetryConfig {
+	return &RetryConfig{
+		MaxAttempts:   3,
+		BackoffMin:    100 * time.Millisecond,
+		BackoffMax:    5 * time.Second,
+		Jitter:        0.2,
+		MaxRetryAfter: 30 * time.Second,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+func (c RetryConfig) withDefaults() RetryConfig {
+	defaults := DefaultRetryConfig()
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaults.MaxAttempts
+	}
+	if c.BackoffMin <= 0 {
+		c.BackoffMin = defaults.BackoffMin
+	}
+	if c.BackoffMax < c.BackoffMin {
+		c.BackoffMax = max(defaults.BackoffMax, c.BackoffMin)
+	}
+	c.Jitter = min(max(c.Jitter, 0), 1)
+	if c.MaxRetryAfter <= 0 {
+		c.MaxRetryAfter = defaults.MaxRetryAfter
+	}
+	if c.RetryableStatusCodes == nil {
+		c.RetryableStatusCodes = defaults.RetryableStatusCodes
+	}
+	return c
+}
+
+// retryTransport retries failed round trips with exponential backoff. A
+// Retry-After header on the response replaces the computed backoff, up to
+// MaxRetryAfter.
+type retryTransport struct {
+	next   http.RoundTripper
+	config RetryConfig
+}
+
+func newRetryTransport(next http.RoundTripper, config RetryConfig) *retryTransport {
+	return &retryTransport{next: next, config: config.withDefaults()}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.config.MaxAttempts < 2 || !t.canRetry(req) || isStreaming(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	req = req.Clone(ctx)
+	if err := bufferBody(req); err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 {
+			attemptReq = req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, fmt.Errorf("failed to rewind request body: %w", err)
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt >= t.config.MaxAttempts || !t.shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get(RetryAfter)); ok {
+				if retryAfter > t.config.MaxRetryAfter {
+					// The server asks for more than we are willing to wait.
+					return resp, err
+				}
+				wait = retryAfter
+			}
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			// Waiting would outlive the caller, hand back what we have.
+			return resp, err
+		}
+		if resp != nil {
+			drainBody(resp)
+		}
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// canRetry reports whether req may be sent more than once. Requests with an
+// Idempotency-Key header are treated as idempotent, like net/http does.
+func (t *retryTransport) canRetry(req *http.Request) bool {
+	if t.config.RetryNonIdempotent {
+		return true
+	}
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != "" || req.Header.Get("X-Idempotency-Key") != ""
+}
+
+func (t *retryTransport) shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return slices.Contains(t.config.RetryableStatusCodes, resp.StatusCode)
+}
+
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	wait := t.config.BackoffMin
+	for i := 1; i < attempt && wait < t.config.BackoffMax; i++ {
+		wait *= 2
+	}
+	wait = min(wait, t.config.BackoffMax)
+
+	if t.config.Jitter > 0 {
+		spread := float64(wait) * t.config.Jitter
+		wait = time.Duration(float64(wait) - spread + rand.Float64()*spread)
+	}
+	return wait
+}
+
+// bufferBody makes the body of req replayable. Bodies created from a
+// bytes.Buffer, bytes.Reader or strings.Reader already have GetBody.
+func bufferBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return nil
+	}
+
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return fmt.Errorf("failed to buffer request body: %w", err)
+	}
+
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	req.Body, _ = req.GetBody()
+	req.ContentLength = int64(len(data))
+	return nil
+}
+
+// drainBody reads a bit of the body before closing it so the connection can be reused.
+func drainBody(resp *http.Response) {
+	_, _ = io.CopyN(io.Discard, resp.Body, 4096)
+	resp.Body.Close()
+}
+
+// parseRetryAfter accepts both forms of Retry-After: delay seconds and an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil || errors.Is(err, strconv.ErrRange) {
+		if seconds < 0 {
+			return 0, false
+		}
+		// Saturate rather than overflow, so huge values still exceed MaxRetryAfter.
+		if seconds > int64(math.MaxInt64/time.Second) {
+			return math.MaxInt64, true
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		// time.Until saturates too for dates far in the future.
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
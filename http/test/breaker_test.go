@@ -0,0 +1,142 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	httpClient "github.com/vixyninja/go-blocks/http"
+)
+
+func TestBreaker_OpensAndRecovers(t *testing.T) {
+	var healthy atomic.Bool
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var transitions []string
+	config := httpClient.DefaultConfig()
+	config.Breaker = &httpClient.BreakerConfig{
+		FailureRate: 0.5,
+		MinRequests: 4,
+		CoolDown:    50 * time.Millisecond,
+		OnStateChange: func(host string, from, to httpClient.CircuitState) {
+			mu.Lock()
+			defer mu.Unlock()
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	}
+	client := httpClient.NewHTTPClient(config)
+	ctx := context.Background()
+
+	for i := 0; i < 4; i++ {
+		resp, err := client.Get(ctx, server.URL, nil)
+		if err != nil {
+			t.Fatalf("Expected no error while closed, got %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	_, err := client.Get(ctx, server.URL, nil)
+	if !errors.Is(err, httpClient.ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if calls.Load() != 4 {
+		t.Errorf("Expected open circuit to skip the network, got %d calls", calls.Load())
+	}
+
+	healthy.Store(true)
+	time.Sleep(60 * time.Millisecond)
+
+	resp, err := client.Get(ctx, server.URL, nil)
+	if err != nil {
+		t.Fatalf("Expected half-open probe to go through, got %v", err)
+	}
+	resp.Body.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"closed->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("Expected transitions %v, got %v", want, transitions)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("Transition %d: expected %s, got %s", i, want[i], transitions[i])
+		}
+	}
+}
+
+func TestBreaker_FailedProbeReopens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := httpClient.DefaultConfig()
+	config.Breaker = &httpClient.BreakerConfig{MinRequests: 1, CoolDown: 20 * time.Millisecond}
+	client := httpClient.NewHTTPClient(config)
+	ctx := context.Background()
+
+	resp, err := client.Get(ctx, server.URL, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+
+	time.Sleep(30 * time.Millisecond)
+	resp, err = client.Get(ctx, server.URL, nil)
+	if err != nil {
+		t.Fatalf("Expected probe to go through, got %v", err)
+	}
+	resp.Body.Close()
+
+	if _, err := client.Get(ctx, server.URL, nil); !errors.Is(err, httpClient.ErrCircuitOpen) {
+		t.Fatalf("Expected circuit to reopen after failed probe, got %v", err)
+	}
+}
+
+func TestBreaker_PerHost(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer down.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer up.Close()
+
+	config := httpClient.DefaultConfig()
+	config.Breaker = &httpClient.BreakerConfig{MinRequests: 1, CoolDown: time.Minute}
+	client := httpClient.NewHTTPClient(config)
+	ctx := context.Background()
+
+	resp, err := client.Get(ctx, down.URL, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+
+	if _, err := client.Get(ctx, down.URL, nil); !errors.Is(err, httpClient.ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen for failing host, got %v", err)
+	}
+
+	resp, err = client.Get(ctx, up.URL, nil)
+	if err != nil {
+		t.Fatalf("Expected healthy host to be unaffected, got %v", err)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,188 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	httpClient "github.com/vixyninja/go-blocks/http"
+	"github.com/vixyninja/go-blocks/http/httpmock"
+)
+
+func TestMockServer_RoutesAndAssertions(t *testing.T) {
+	srv := httpmock.NewServer(t)
+	srv.On(http.MethodGet, "/users").Query("page", "2").
+		ReplyJSON(http.StatusOK, []user{{ID: 3, Name: "carol"}}).
+		Once()
+	srv.On(http.MethodPost, "/users").JSONBody(user{Name: "dave"}).
+		ReplyJSON(http.StatusCreated, user{ID: 4, Name: "dave"}).
+		ReplyHeader("Location", "/users/4")
+
+	client := httpClient.NewHTTPClient(nil)
+	ctx := context.Background()
+
+	users, err := httpClient.GetJSON[[]user](ctx, client, srv.URL+"/users?page=2")
+	if err != nil || len(users) != 1 || users[0].Name != "carol" {
+		t.Fatalf("Unexpected users %v (%v)", users, err)
+	}
+
+	created, err := httpClient.PostJSON[user, user](ctx, client, srv.URL+"/users", user{Name: "dave"})
+	if err != nil || created.ID != 4 {
+		t.Fatalf("Unexpected user %v (%v)", created, err)
+	}
+
+	srv.AssertExpectations(t)
+	if len(srv.Requests()) != 2 {
+		t.Errorf("Expected 2 recorded requests, got %d", len(srv.Requests()))
+	}
+}
+
+func TestMockServer_ReportsUnmatchedAndMissedRoutes(t *testing.T) {
+	srv := httpmock.NewServer(t)
+	srv.On(http.MethodGet, "/expected").Reply(http.StatusOK)
+
+	resp, err := httpClient.NewHTTPClient(nil).Get(context.Background(), srv.URL+"/other", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 for unmatched request, got %d", resp.StatusCode)
+	}
+
+	ft := &fakeTB{TB: t}
+	srv.AssertExpectations(ft)
+	if len(ft.errors) != 2 {
+		t.Errorf("Expected a missed route and an unmatched request, got %v", ft.errors)
+	}
+}
+
+func TestMockServer_SequenceAndFaults(t *testing.T) {
+	srv := httpmock.NewServer(t)
+	srv.On(http.MethodGet, "/flaky").
+		Reply(http.StatusServiceUnavailable).
+		Reply(http.StatusOK)
+	srv.On(http.MethodGet, "/reset").Fault(httpmock.FaultConnectionReset)
+	srv.On(http.MethodGet, "/slow").Reply(http.StatusOK).Delay(time.Second)
+
+	config := retryConfig()
+	client := httpClient.NewHTTPClient(config)
+	ctx := context.Background()
+
+	resp, err := client.Get(ctx, srv.URL+"/flaky", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected retry to reach the second response, got %d", resp.StatusCode)
+	}
+
+	if _, err := httpClient.NewHTTPClient(nil).Get(ctx, srv.URL+"/reset", nil); err == nil {
+		t.Error("Expected connection error")
+	}
+
+	_, err = client.NewRequest(http.MethodGet, srv.URL+"/slow").Timeout(20 * time.Millisecond).Do(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestRecorder_RecordThenReplay(t *testing.T) {
+	golden := filepath.Join(t.TempDir(), "users.json")
+
+	srv := httpmock.NewServer(t)
+	srv.On(http.MethodGet, "/users/1").ReplyJSON(http.StatusOK, user{ID: 1, Name: "alice"})
+
+	rec, err := httpmock.NewRecorder(golden, httpmock.ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	config := httpClient.DefaultConfig()
+	config.Middleware = []httpClient.Middleware{rec.Middleware()}
+
+	recorded, err := httpClient.GetJSON[user](context.Background(), httpClient.NewHTTPClient(config), srv.URL+"/users/1",
+		httpClient.WithHeader("Authorization", "Bearer secret"))
+	if err != nil {
+		t.Fatalf("Expected no error while recording, got %v", err)
+	}
+	srv.Close()
+
+	replay, err := httpmock.NewRecorder(golden, httpmock.ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("Expected golden file, got %v", err)
+	}
+	config = httpClient.DefaultConfig()
+	config.Middleware = []httpClient.Middleware{replay.Middleware()}
+	client := httpClient.NewHTTPClient(config)
+
+	replayed, err := httpClient.GetJSON[user](context.Background(), client, srv.URL+"/users/1")
+	if err != nil {
+		t.Fatalf("Expected replay with the server closed, got %v", err)
+	}
+	if replayed != recorded {
+		t.Errorf("Expected %+v, got %+v", recorded, replayed)
+	}
+
+	_, err = client.Get(context.Background(), srv.URL+"/users/2", nil)
+	if !errors.Is(err, httpmock.ErrNoInteraction) {
+		t.Errorf("Expected ErrNoInteraction, got %v", err)
+	}
+}
+
+func TestRecorder_ReplaysBinaryBodies(t *testing.T) {
+	golden := filepath.Join(t.TempDir(), "image.json")
+	reqBody := []byte{0x89, 'P', 'N', 'G', 0xff, 0x00, 0xfe}
+	respBody := []byte{0xff, 0xd8, 0xff, 0xe0, 0x00, 0x80}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Write(respBody)
+	}))
+	defer srv.Close()
+
+	roundTrip := func(rt http.RoundTripper) []byte {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+"/thumbnails", bytes.NewReader(reqBody))
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return body
+	}
+
+	rec, err := httpmock.NewRecorder(golden, httpmock.ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	roundTrip(rec)
+
+	replay, err := httpmock.NewRecorder(golden, httpmock.ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("Expected golden file, got %v", err)
+	}
+	if got := roundTrip(replay); !bytes.Equal(got, respBody) {
+		t.Errorf("Expected %x, got %x", respBody, got)
+	}
+}
+
+// fakeTB collects errors instead of failing the test, to check the assertions themselves.
+type fakeTB struct {
+	testing.TB
+	errors []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,134 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpClient "github.com/vixyninja/go-blocks/http"
+	"github.com/vixyninja/go-blocks/response"
+)
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestGetJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/json" {
+			t.Errorf("Expected Accept: application/json, got %s", r.Header.Get("Accept"))
+		}
+		if r.Header.Get("X-Tenant") != "acme" {
+			t.Errorf("Expected X-Tenant: acme, got %s", r.Header.Get("X-Tenant"))
+		}
+		w.Write([]byte(`{"id": 1, "name": "alice"}`))
+	}))
+	defer server.Close()
+
+	got, err := httpClient.GetJSON[user](context.Background(), httpClient.NewHTTPClient(nil), server.URL,
+		httpClient.WithHeader("X-Tenant", "acme"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got != (user{ID: 1, Name: "alice"}) {
+		t.Errorf("Unexpected user %+v", got)
+	}
+}
+
+func TestPostJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in user
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Fatalf("Expected valid JSON, got error: %v", err)
+		}
+		in.ID = 7
+		response.RespondCreated(w, r, in)
+	}))
+	defer server.Close()
+
+	got, err := httpClient.PostJSON[user, response.Response[user]](context.Background(),
+		httpClient.NewHTTPClient(nil), server.URL, user{Name: "bob"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.Data.ID != 7 || got.Data.Name != "bob" {
+		t.Errorf("Unexpected response %+v", got)
+	}
+}
+
+func TestJSON_StatusErrorDecodesErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response.NotFound(w, r, "user not found")
+	}))
+	defer server.Close()
+
+	_, err := httpClient.GetJSON[user](context.Background(), httpClient.NewHTTPClient(nil), server.URL)
+
+	var statusErr *httpClient.StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("Expected *StatusError, got %T: %v", err, err)
+	}
+	if statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", statusErr.StatusCode)
+	}
+	if statusErr.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("Expected headers to be kept, got %v", statusErr.Header)
+	}
+	if statusErr.Response == nil || statusErr.Response.Code != "not_found" || statusErr.Response.Message != "user not found" {
+		t.Errorf("Expected decoded ErrorResponse, got %+v", statusErr.Response)
+	}
+}
+
+func TestJSON_StatusErrorTruncatesBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(strings.Repeat("x", 10000)))
+	}))
+	defer server.Close()
+
+	_, err := httpClient.GetJSON[user](context.Background(), httpClient.NewHTTPClient(nil), server.URL)
+
+	var statusErr *httpClient.StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("Expected *StatusError, got %v", err)
+	}
+	if len(statusErr.Body) != 4096 {
+		t.Errorf("Expected body truncated to 4096 bytes, got %d", len(statusErr.Body))
+	}
+	if statusErr.Response != nil {
+		t.Errorf("Expected no ErrorResponse for a plain body, got %+v", statusErr.Response)
+	}
+}
+
+func TestJSON_MaxBodySize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "name": "` + strings.Repeat("a", 100) + `"}`))
+	}))
+	defer server.Close()
+
+	_, err := httpClient.GetJSON[user](context.Background(), httpClient.NewHTTPClient(nil), server.URL,
+		httpClient.WithMaxBodySize(32))
+	if !errors.Is(err, httpClient.ErrBodyTooLarge) {
+		t.Fatalf("Expected ErrBodyTooLarge, got %v", err)
+	}
+}
+
+func TestJSON_EmptyBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	got, err := httpClient.DeleteJSON[*user](context.Background(), httpClient.NewHTTPClient(nil), server.URL)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got != nil {
+		t.Errorf("Expected zero value, got %+v", got)
+	}
+}
@@ -0,0 +1,115 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpClient "github.com/vixyninja/go-blocks/http"
+	"github.com/vixyninja/go-blocks/logx"
+	"github.com/vixyninja/go-blocks/logx/logxtest"
+)
+
+func TestMiddleware_OrderAndRequestID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Request-Id") != "req-42" {
+			t.Errorf("Expected X-Request-Id: req-42, got %q", r.Header.Get("X-Request-Id"))
+		}
+		if r.Header.Get("X-Order") != "outer,inner" {
+			t.Errorf("Expected outer middleware to run first, got %q", r.Header.Get("X-Order"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tag := func(name string) httpClient.Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return httpClient.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				req = req.Clone(req.Context())
+				order := req.Header.Get("X-Order")
+				if order != "" {
+					order += ","
+				}
+				req.Header.Set("X-Order", order+name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	config := httpClient.DefaultConfig()
+	config.Middleware = []httpClient.Middleware{tag("outer"), tag("inner"), httpClient.RequestID()}
+	client := httpClient.NewHTTPClient(config)
+
+	ctx := logx.ContextWithRequestID(context.Background(), "req-42")
+	resp, err := client.Get(ctx, server.URL, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestMiddleware_LoggingRedactsAuthorization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("Expected the real Authorization header to be sent, got %q", r.Header.Get("Authorization"))
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	rec := logxtest.NewRecorder()
+	config := httpClient.DefaultConfig()
+	config.Middleware = []httpClient.Middleware{httpClient.Logging(rec, httpClient.LoggingConfig{Headers: true})}
+	client := httpClient.NewHTTPClient(config)
+
+	ctx := logx.ContextWithRequestID(context.Background(), "req-7")
+	resp, err := client.Get(ctx, server.URL+"/health", httpClient.BearerTokenHeaders("secret"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+
+	entries := rec.FilterLevel(logx.LevelWarn)
+	if len(entries) != 1 {
+		t.Fatalf("Expected one warn entry for a 503, got %v", rec.Entries())
+	}
+	e := entries[0]
+
+	if e.Fields["status"] != int64(http.StatusServiceUnavailable) {
+		t.Errorf("Expected status field, got %v", e.Fields["status"])
+	}
+	if url, _ := e.Fields["url"].(string); !strings.HasSuffix(url, "/health") {
+		t.Errorf("Expected url field, got %v", e.Fields["url"])
+	}
+	if _, ok := e.Fields["latency"]; !ok {
+		t.Error("Expected latency field")
+	}
+	if e.ContextFields[logx.RequestIDField] != "req-7" {
+		t.Errorf("Expected request id from context, got %v", e.ContextFields)
+	}
+
+	headers, _ := e.Fields["request_headers"].(http.Header)
+	if headers.Get("Authorization") != "[REDACTED]" {
+		t.Errorf("Expected Authorization to be redacted, got %q", headers.Get("Authorization"))
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer x")
+	h.Set("X-Api-Key", "k")
+	h.Set("Accept", "application/json")
+
+	out := httpClient.RedactHeaders(h, "Authorization", "x-api-key")
+	if out.Get("Authorization") != "[REDACTED]" || out.Get("X-Api-Key") != "[REDACTED]" {
+		t.Errorf("Expected headers redacted, got %v", out)
+	}
+	if out.Get("Accept") != "application/json" {
+		t.Errorf("Expected other headers kept, got %v", out)
+	}
+	if h.Get("Authorization") != "Bearer x" {
+		t.Error("Expected the original header to be untouched")
+	}
+}
@@ -0,0 +1,163 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpClient "github.com/vixyninja/go-blocks/http"
+)
+
+func TestRequestBuilder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Expected PATCH, got %s", r.Method)
+		}
+		if got := r.URL.Query()["tag"]; len(got) != 2 || got[0] != "a" || got[1] != "b" {
+			t.Errorf("Expected tag=a&tag=b, got %v", got)
+		}
+		if r.URL.Query().Get("existing") != "1" {
+			t.Errorf("Expected query from the URL to be kept, got %s", r.URL.RawQuery)
+		}
+		if got := r.Header.Values("X-Multi"); len(got) != 2 {
+			t.Errorf("Expected 2 X-Multi values, got %v", got)
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected Content-Type: application/json, got %s", r.Header.Get("Content-Type"))
+		}
+		if r.Header.Get("User-Agent") != "Gaia-Client/1.0" {
+			t.Errorf("Expected default User-Agent, got %s", r.Header.Get("User-Agent"))
+		}
+
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Expected valid JSON, got error: %v", err)
+		}
+		if body["name"] != "test" {
+			t.Errorf("Expected name: test, got %v", body["name"])
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := httpClient.NewHTTPClient(nil)
+	resp, err := client.NewRequest(http.MethodPatch, server.URL+"/items/1?existing=1").
+		Query("tag", "a", "b").
+		Header("X-Multi", "one").
+		Header("X-Multi", "two").
+		JSON(map[string]string{"name": "test"}).
+		Do(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestRequestBuilder_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	_, err := httpClient.NewHTTPClient(nil).NewRequest(http.MethodGet, server.URL).
+		Timeout(20 * time.Millisecond).
+		Do(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestRequestBuilder_TimeoutAllowsReadingBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("payload"))
+	}))
+	defer server.Close()
+
+	resp, err := httpClient.NewHTTPClient(nil).NewRequest(http.MethodGet, server.URL).
+		Timeout(time.Second).
+		Do(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil || string(body) != "payload" {
+		t.Fatalf("Expected body 'payload', got %q (%v)", body, err)
+	}
+}
+
+func TestHTTPClient_Do(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Default") != "default-value" {
+			t.Errorf("Expected default header on prepared request, got %q", r.Header.Get("X-Default"))
+		}
+		if r.Header.Get("User-Agent") != "Own-Agent" {
+			t.Errorf("Expected request User-Agent to win, got %s", r.Header.Get("User-Agent"))
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	config := httpClient.DefaultConfig()
+	config.DefaultHeaders["X-Default"] = "default-value"
+	client := httpClient.NewHTTPClient(config)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("x"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	req.Header.Set("User-Agent", "Own-Agent")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("Expected status 202, got %d", resp.StatusCode)
+	}
+}
+
+func TestHTTPClient_HeadAndOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Method", r.Method)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := httpClient.NewHTTPClient(nil)
+	ctx := context.Background()
+
+	resp, err := client.Head(ctx, server.URL, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+	if resp.Header.Get("X-Method") != http.MethodHead {
+		t.Errorf("Expected HEAD, got %s", resp.Header.Get("X-Method"))
+	}
+
+	resp, err = client.Options(ctx, server.URL, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+	if resp.Header.Get("X-Method") != http.MethodOptions {
+		t.Errorf("Expected OPTIONS, got %s", resp.Header.Get("X-Method"))
+	}
+}
@@ -0,0 +1,213 @@
+package http_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	httpClient "github.com/vixyninja/go-blocks/http"
+)
+
+func retryConfig() *httpClient.Config {
+	config := httpClient.DefaultConfig()
+	config.Retry = &httpClient.RetryConfig{
+		MaxAttempts: 3,
+		BackoffMin:  time.Millisecond,
+		BackoffMax:  5 * time.Millisecond,
+	}
+	return config
+}
+
+func TestRetry_RetriesTransientStatus(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	resp, err := httpClient.NewHTTPClient(retryConfig()).Get(context.Background(), server.URL, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	resp, err := httpClient.NewHTTPClient(retryConfig()).Get(context.Background(), server.URL, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("Expected last status 502, got %d", resp.StatusCode)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestRetry_ReplaysBodyForIdempotentMethods(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "payload" {
+			t.Errorf("Attempt %d: expected body 'payload', got %q", calls.Load()+1, body)
+		}
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// io.MultiReader has no GetBody, so the client must buffer it.
+	body := io.MultiReader(strings.NewReader("pay"), strings.NewReader("load"))
+	resp, err := httpClient.NewHTTPClient(retryConfig()).Put(context.Background(), server.URL, body, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 attempts, got %d", calls.Load())
+	}
+}
+
+func TestRetry_SkipsNonIdempotentByDefault(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := httpClient.NewHTTPClient(retryConfig())
+	resp, err := client.Post(context.Background(), server.URL, strings.NewReader("{}"), nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected POST to be sent once, got %d", calls.Load())
+	}
+
+	calls.Store(0)
+	headers := map[string]string{"Idempotency-Key": "abc"}
+	resp, err = client.Post(context.Background(), server.URL, strings.NewReader("{}"), headers)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+
+	if calls.Load() != 3 {
+		t.Errorf("Expected POST with Idempotency-Key to be retried, got %d attempts", calls.Load())
+	}
+}
+
+func TestRetry_HonorsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	var first time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if elapsed := time.Since(first); elapsed < time.Second {
+			t.Errorf("Expected retry after at least 1s, got %v", elapsed)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	resp, err := httpClient.NewHTTPClient(retryConfig()).Get(context.Background(), server.URL, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestRetry_StopsWhenRetryAfterExceedsDeadline(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	resp, err := httpClient.NewHTTPClient(retryConfig()).Get(ctx, server.URL, nil)
+	if err != nil {
+		t.Fatalf("Expected the last response, got error %v", err)
+	}
+	defer resp.Body.Close()
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected a single attempt, got %d", calls.Load())
+	}
+}
+
+func TestRetry_StopsWhenRetryAfterExceedsMax(t *testing.T) {
+	// 9223372037 seconds overflows time.Duration.
+	for _, retryAfter := range []string{"86400", "9223372037", "99999999999999999999"} {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.Header().Set("Retry-After", retryAfter)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+
+		config := retryConfig()
+		config.Timeout = 0
+		config.Retry.MaxRetryAfter = time.Second
+
+		start := time.Now()
+		resp, err := httpClient.NewHTTPClient(config).Get(context.Background(), server.URL, nil)
+		if err != nil {
+			t.Fatalf("Retry-After %s: expected the last response, got error %v", retryAfter, err)
+		}
+		resp.Body.Close()
+		server.Close()
+
+		if resp.StatusCode != http.StatusServiceUnavailable || calls.Load() != 1 {
+			t.Errorf("Retry-After %s: expected a single 503, got %d after %d calls", retryAfter, resp.StatusCode, calls.Load())
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("Retry-After %s: expected no wait, took %v", retryAfter, elapsed)
+		}
+	}
+}
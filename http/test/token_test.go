@@ -0,0 +1,158 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	httpClient "github.com/vixyninja/go-blocks/http"
+)
+
+func newTokenServer(t *testing.T, issued *atomic.Int32, expiresIn int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "svc" || secret != "s3cret" {
+			t.Errorf("Expected client credentials in basic auth, got %q %q", id, secret)
+		}
+		if r.FormValue("grant_type") != "client_credentials" || r.FormValue("scope") != "read write" {
+			t.Errorf("Unexpected token form %v", r.Form)
+		}
+		time.Sleep(10 * time.Millisecond)
+		n := issued.Add(1)
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": fmt.Sprintf("token-%d", n),
+			"token_type":   "bearer",
+			"expires_in":   expiresIn,
+		})
+	}))
+}
+
+func newCredentialsSource(url string) *httpClient.ClientCredentialsSource {
+	return httpClient.NewClientCredentialsSource(httpClient.ClientCredentialsConfig{
+		TokenURL:     url,
+		ClientID:     "svc",
+		ClientSecret: "s3cret",
+		Scopes:       []string{"read", "write"},
+	})
+}
+
+func TestClientCredentials_CachesAndSharesRefresh(t *testing.T) {
+	var issued atomic.Int32
+	tokenServer := newTokenServer(t, &issued, 3600)
+	defer tokenServer.Close()
+
+	source := newCredentialsSource(tokenServer.URL)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := source.Token(context.Background())
+			if err != nil || token.AccessToken != "token-1" {
+				t.Errorf("Expected token-1, got %v (%v)", token, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, err := source.Token(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if issued.Load() != 1 {
+		t.Errorf("Expected a single token request, got %d", issued.Load())
+	}
+}
+
+func TestClientCredentials_RefreshesBeforeExpiry(t *testing.T) {
+	var issued atomic.Int32
+	// Lives for less than the default 30s expiry delta, so the delta is
+	// limited to half of it: reused at first, refreshed after 500ms.
+	tokenServer := newTokenServer(t, &issued, 1)
+	defer tokenServer.Close()
+
+	source := newCredentialsSource(tokenServer.URL)
+	first, _ := source.Token(context.Background())
+	second, _ := source.Token(context.Background())
+	if first.AccessToken != second.AccessToken {
+		t.Errorf("Expected a short-lived token to be reused, got %s then %s", first.AccessToken, second.AccessToken)
+	}
+
+	time.Sleep(600 * time.Millisecond)
+	third, _ := source.Token(context.Background())
+	if third.AccessToken == first.AccessToken {
+		t.Errorf("Expected a new token before expiry, got %s again", third.AccessToken)
+	}
+}
+
+func TestBearerAuth_RetriesOnceOn401(t *testing.T) {
+	var issued atomic.Int32
+	tokenServer := newTokenServer(t, &issued, 3600)
+	defer tokenServer.Close()
+
+	var calls atomic.Int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "payload" {
+			t.Errorf("Expected body on every attempt, got %q", body)
+		}
+		// The first token is revoked on the server side.
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+
+	config := httpClient.DefaultConfig()
+	config.TokenSource = newCredentialsSource(tokenServer.URL)
+	client := httpClient.NewHTTPClient(config)
+
+	resp, err := client.NewRequest(http.MethodPost, api.URL).Body(io.MultiReader(strings.NewReader("payload"))).Do(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200 after refresh, got %d", resp.StatusCode)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls.Load())
+	}
+}
+
+func TestBearerAuth_StaticTokenDoesNotRetry(t *testing.T) {
+	var calls atomic.Int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("Authorization") != "Bearer fixed" {
+			t.Errorf("Expected static bearer token, got %q", r.Header.Get("Authorization"))
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer api.Close()
+
+	config := httpClient.DefaultConfig()
+	config.TokenSource = httpClient.StaticTokenSource("fixed")
+
+	resp, err := httpClient.NewHTTPClient(config).Get(context.Background(), api.URL, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized || calls.Load() != 1 {
+		t.Errorf("Expected a single 401, got %d after %d calls", resp.StatusCode, calls.Load())
+	}
+}
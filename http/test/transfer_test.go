@@ -0,0 +1,284 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	httpClient "github.com/vixyninja/go-blocks/http"
+)
+
+func TestFormBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+			t.Errorf("Expected form Content-Type, got %s", r.Header.Get("Content-Type"))
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Expected valid form, got error: %v", err)
+		}
+		if got := r.PostForm["tag"]; len(got) != 2 || r.PostForm.Get("name") != "a b&c" {
+			t.Errorf("Unexpected form: %v", r.PostForm)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := httpClient.NewHTTPClient(nil)
+	resp, err := client.NewRequest(http.MethodPost, server.URL).
+		Form(url.Values{"name": {"a b&c"}, "tag": {"x", "y"}}).
+		Do(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestMultipartUpload(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		reader, err := r.MultipartReader()
+		if err != nil {
+			t.Fatalf("Expected multipart body, got error: %v", err)
+		}
+
+		parts := map[string]string{}
+		for {
+			part, err := reader.NextPart()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Expected valid part, got error: %v", err)
+			}
+			data, _ := io.ReadAll(part)
+			parts[part.FormName()] = string(data)
+			if part.FormName() == "file" && part.FileName() != "report.csv" {
+				t.Errorf("Expected filename report.csv, got %s", part.FileName())
+			}
+		}
+		if parts["title"] != "report" || parts["file"] != "a,b\n1,2\n" {
+			t.Errorf("Unexpected parts: %v", parts)
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := retryConfig()
+	config.Retry.RetryNonIdempotent = true
+	client := httpClient.NewHTTPClient(config)
+
+	// The pipe hides the content from anything that would buffer it up front.
+	pr, pw := io.Pipe()
+	go func() {
+		pw.Write([]byte("a,b\n"))
+		pw.Write([]byte("1,2\n"))
+		pw.Close()
+	}()
+
+	form := httpClient.NewMultipart().
+		Field("title", "report").
+		FileWithType("file", "report.csv", "text/csv", pr)
+	resp, err := client.NewRequest(http.MethodPost, server.URL).Multipart(form).Do(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected a streamed body to be sent once, got %d calls", calls.Load())
+	}
+}
+
+func TestStreamBodyNotBuffered(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if data, _ := io.ReadAll(r.Body); string(data) != "chunk 1\nchunk 2\n" {
+			t.Errorf("Unexpected body %q", data)
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := retryConfig()
+	config.Retry.RetryNonIdempotent = true
+	client := httpClient.NewHTTPClient(config)
+
+	// The pipe hides the content from anything that would buffer it up front.
+	pr, pw := io.Pipe()
+	go func() {
+		pw.Write([]byte("chunk 1\n"))
+		pw.Write([]byte("chunk 2\n"))
+		pw.Close()
+	}()
+
+	resp, err := client.Put(context.Background(), server.URL, httpClient.StreamBody(io.Reader(pr)), nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected a streamed body to be sent once, got %d calls", calls.Load())
+	}
+}
+
+func TestDownload(t *testing.T) {
+	content := strings.Repeat("0123456789", 1000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "data.bin", time.Time{}, strings.NewReader(content))
+	}))
+	defer server.Close()
+
+	client := httpClient.NewHTTPClient(nil)
+
+	var last, total int64
+	var buf bytes.Buffer
+	n, err := httpClient.Download(context.Background(), client, server.URL, &buf,
+		httpClient.WithProgress(func(done, size int64) { last, total = done, size }))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n != int64(len(content)) || buf.String() != content {
+		t.Errorf("Expected %d bytes, got %d", len(content), n)
+	}
+	if last != int64(len(content)) || total != int64(len(content)) {
+		t.Errorf("Expected final progress %d/%d, got %d/%d", len(content), len(content), last, total)
+	}
+}
+
+func TestDownloadStatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gone", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := httpClient.Download(context.Background(), httpClient.NewHTTPClient(nil), server.URL, io.Discard)
+	var statusErr *httpClient.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("Expected a 404 StatusError, got %v", err)
+	}
+}
+
+func TestDownloadFileResume(t *testing.T) {
+	content := strings.Repeat("abcdefghij", 500)
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, "data.bin", time.Time{}, strings.NewReader(content))
+	}))
+	defer server.Close()
+
+	client := httpClient.NewHTTPClient(nil)
+	path := filepath.Join(t.TempDir(), "data.bin")
+	if err := os.WriteFile(path, []byte(content[:1200]), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var first int64 = -1
+	n, err := httpClient.DownloadFile(context.Background(), client, server.URL, path,
+		httpClient.WithProgress(func(done, total int64) {
+			if first < 0 {
+				first = done
+			}
+		}))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n != int64(len(content)-1200) {
+		t.Errorf("Expected %d resumed bytes, got %d", len(content)-1200, n)
+	}
+	if len(ranges) != 1 || ranges[0] != "bytes=1200-" {
+		t.Errorf("Expected Range bytes=1200-, got %v", ranges)
+	}
+	if first <= 1200 {
+		t.Errorf("Expected progress to count the resumed part, got %d", first)
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != content {
+		t.Errorf("Expected the full file after resume, got %d bytes", len(data))
+	}
+
+	// A complete file is left alone.
+	n, err = httpClient.DownloadFile(context.Background(), client, server.URL, path)
+	if err != nil || n != 0 {
+		t.Errorf("Expected nothing to download, got %d bytes, error %v", n, err)
+	}
+}
+
+func TestDownloadFileChangedDuringResume(t *testing.T) {
+	oldContent := strings.Repeat("a", 5000)
+	newContent := strings.Repeat("b", 6000)
+	var (
+		requests atomic.Int32
+		ifRange  atomic.Value
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			// Break off the first download halfway.
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Content-Length", strconv.Itoa(len(oldContent)))
+			w.Write([]byte(oldContent[:1200]))
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		ifRange.Store(r.Header.Get("If-Range"))
+		w.Header().Set("ETag", `"v2"`)
+		http.ServeContent(w, r, "data.bin", time.Time{}, strings.NewReader(newContent))
+	}))
+	defer server.Close()
+
+	client := httpClient.NewHTTPClient(nil)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.bin")
+	if _, err := httpClient.DownloadFile(context.Background(), client, server.URL, path); err == nil {
+		t.Fatal("Expected the interrupted download to fail")
+	}
+
+	if _, err := httpClient.DownloadFile(context.Background(), client, server.URL, path); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := ifRange.Load(); got != `"v1"` {
+		t.Errorf("Expected If-Range \"v1\", got %v", got)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != newContent {
+		t.Errorf("Expected the changed file to be downloaded again, got %d bytes", len(data))
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("Expected only the downloaded file to remain, got %v", entries)
+	}
+}
+
+func TestDownloadFileRangeIgnored(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("fresh"))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "data.bin")
+	if err := os.WriteFile(path, []byte("stale content"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := httpClient.DownloadFile(context.Background(), httpClient.NewHTTPClient(nil), server.URL, path); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "fresh" {
+		t.Errorf("Expected the file to be rewritten, got %q", data)
+	}
+}
@@ -0,0 +1,149 @@
+package http_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	httpClient "github.com/vixyninja/go-blocks/http"
+)
+
+func clientCertificate(t *testing.T) (certPEM, keyPEM []byte, cert *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	cert, _ = x509.ParseCertificate(der)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, cert
+}
+
+func TestTransport_MutualTLSFromFiles(t *testing.T) {
+	certPEM, keyPEM, cert := clientCertificate(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "client" {
+			t.Errorf("Expected client certificate, got %v", r.TLS.PeerCertificates)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(cert)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client-key.pem")
+	os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600)
+	os.WriteFile(certFile, certPEM, 0o600)
+	os.WriteFile(keyFile, keyPEM, 0o600)
+
+	config := httpClient.DefaultConfig()
+	config.Transport = &httpClient.TransportConfig{
+		CAFile:       caFile,
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		DisableProxy: true,
+	}
+	client, err := httpClient.BuildHTTPClient(config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	resp, err := client.Get(context.Background(), server.URL, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestTransport_UnknownCAIsRejected(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, err := httpClient.NewHTTPClient(nil).Get(context.Background(), server.URL, nil)
+	if err == nil {
+		t.Fatal("Expected certificate verification error")
+	}
+}
+
+func TestTransport_InvalidConfig(t *testing.T) {
+	cases := map[string]httpClient.TransportConfig{
+		"missing CA file":  {CAFile: filepath.Join(t.TempDir(), "missing.pem")},
+		"garbage CA":       {CAPEM: []byte("not a certificate")},
+		"cert without key": {CertPEM: []byte("-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n")},
+		"bad proxy":        {ProxyURL: "://nope"},
+	}
+
+	for name, transport := range cases {
+		t.Run(name, func(t *testing.T) {
+			config := httpClient.DefaultConfig()
+			config.Transport = &transport
+
+			if _, err := httpClient.BuildHTTPClient(config); err == nil {
+				t.Fatal("Expected BuildHTTPClient to fail")
+			}
+
+			// NewHTTPClient still returns a client, whose requests fail with the same error.
+			if _, err := httpClient.NewHTTPClient(config).Get(context.Background(), "http://example.invalid", nil); err == nil {
+				t.Fatal("Expected requests to fail")
+			}
+		})
+	}
+}
+
+func TestNewTransport_Defaults(t *testing.T) {
+	transport, err := httpClient.NewTransport(httpClient.TransportConfig{DisableHTTP2: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if transport.MaxIdleConnsPerHost != 100 {
+		t.Errorf("Expected 100 idle connections per host, got %d", transport.MaxIdleConnsPerHost)
+	}
+	if transport.TLSClientConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("Expected TLS 1.2 minimum, got %x", transport.TLSClientConfig.MinVersion)
+	}
+	if transport.ForceAttemptHTTP2 || transport.TLSNextProto == nil {
+		t.Error("Expected HTTP/2 to be disabled")
+	}
+}
@@ -0,0 +1,87 @@
+package http_test
+
+import (
+	"errors"
+	"testing"
+
+	httpClient "github.com/vixyninja/go-blocks/http"
+)
+
+func TestURLBuilder(t *testing.T) {
+	tests := []struct {
+		name     string
+		builder  *httpClient.URLBuilder
+		expected string
+	}{
+		{
+			"escapes query values",
+			httpClient.NewURL("https://api.example.com").Query("q", "a b&c=d", "ünï"),
+			"https://api.example.com?q=a+b%26c%3Dd&q=%C3%BCn%C3%AF",
+		},
+		{
+			"merges with the base query",
+			httpClient.NewURL("https://api.example.com/search?key=abc&tag=x").Query("tag", "y"),
+			"https://api.example.com/search?key=abc&tag=x&tag=y",
+		},
+		{
+			"expands path parameters",
+			httpClient.NewURL("https://api.example.com/v1/").
+				Path("/users/{id}/files/{name}").
+				Param("id", "42").
+				Param("name", "a/b c.txt"),
+			"https://api.example.com/v1/users/42/files/a%2Fb%20c.txt",
+		},
+		{
+			"keeps params map order stable",
+			httpClient.NewURL("https://api.example.com").Params(map[string]string{"page": "1", "limit": "10"}),
+			"https://api.example.com?limit=10&page=1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.builder.String()
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if result != test.expected {
+				t.Errorf("Expected %s, got %s", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestURLBuilder_Errors(t *testing.T) {
+	for _, base := range []string{"", "/relative", "://bad", "http://[::1"} {
+		if _, err := httpClient.NewURL(base).String(); !errors.Is(err, httpClient.ErrInvalidBaseURL) {
+			t.Errorf("Expected ErrInvalidBaseURL for %q, got %v", base, err)
+		}
+	}
+
+	if _, err := httpClient.NewURL("https://api.example.com").Path("/users/{id}").String(); err == nil {
+		t.Error("Expected an error for a missing path parameter")
+	}
+	if _, err := httpClient.NewURL("https://api.example.com").Path("/users/{id").String(); err == nil {
+		t.Error("Expected an error for an unclosed path parameter")
+	}
+	for _, value := range []string{".", ".."} {
+		u, err := httpClient.NewURL("https://api.example.com/v1").Path("/users/{id}/profile").Param("id", value).String()
+		if err == nil {
+			t.Errorf("Expected an error for path parameter %q, got %s", value, u)
+		}
+	}
+}
+
+func TestBuildURL_Escapes(t *testing.T) {
+	result := httpClient.BuildURL("https://api.example.com?page=1", map[string]string{"q": "a&b"})
+	if result != "https://api.example.com?page=1&q=a%26b" {
+		t.Errorf("Expected the existing query to be merged and escaped, got %s", result)
+	}
+}
+
+func TestBuildURL_RelativeBase(t *testing.T) {
+	result := httpClient.BuildURL("/api/users", map[string]string{"page": "1"})
+	if result != "/api/users?page=1" {
+		t.Errorf("Expected a relative base to keep working, got %s", result)
+	}
+}
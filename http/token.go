@@ -0,0 +1,284 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultExpiryDelta is how long before expiry a cached token is refreshed.
+	DefaultExpiryDelta = 30 * time.Second
+
+	tokenFetchTimeout = 30 * time.Second
+)
+
+type Token struct {
+	AccessToken string
+	TokenType   string    // "Bearer" when empty
+	Expiry      time.Time // zero means the token does not expire
+}
+
+// Valid reports whether t can still be used for at least delta.
+func (t *Token) Valid(delta time.Duration) bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Add(delta).Before(t.Expiry)
+}
+
+func (t *Token) header() string {
+	if t.TokenType == "" || strings.EqualFold(t.TokenType, "bearer") {
+		return "Bearer " + t.AccessToken
+	}
+	return t.TokenType + " " + t.AccessToken
+}
+
+// TokenSource supplies the token sent in the Authorization header. Sources
+// that cache tokens can implement Invalidate(*Token) so a 401 forces a refresh.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+type tokenInvalidator interface {
+	Invalidate(token *Token)
+}
+
+type staticTokenSource struct {
+	token *Token
+}
+
+// StaticTokenSource always returns the same bearer token.
+func StaticTokenSource(token string) TokenSource {
+	return &staticTokenSource{token: &Token{AccessToken: token}}
+}
+
+func (s *staticTokenSource) Token(context.Context) (*Token, error) {
+	return s.token, nil
+}
+
+type ClientCredentialsConfig struct {
+	TokenURL       string
+	ClientID       string
+	ClientSecret   string
+	Scopes         []string
+	EndpointParams url.Values    // extra form values, such as "audience"
+	ExpiryDelta    time.Duration // refresh this long before expiry (default: DefaultExpiryDelta)
+	Client         *http.Client  // client for the token endpoint (default: http.DefaultClient)
+}
+
+// ClientCredentialsSource fetches tokens with the OAuth2 client credentials
+// grant and caches them until shortly before they expire. Concurrent callers
+// share a single refresh.
+type ClientCredentialsSource struct {
+	config ClientCredentialsConfig
+
+	mu       sync.Mutex
+	token    *Token
+	delta    time.Duration // ExpiryDelta, limited to half of the token's lifetime
+	inflight *tokenCall
+}
+
+type tokenCall struct {
+	done  chan struct{}
+	token *Token
+	err   error
+}
+
+func NewClientCredentialsSource(config ClientCredentialsConfig) *ClientCredentialsSource {
+	if config.ExpiryDelta <= 0 {
+		config.ExpiryDelta = DefaultExpiryDelta
+	}
+	if config.Client == nil {
+		config.Client = http.DefaultClient
+	}
+	return &ClientCredentialsSource{config: config}
+}
+
+func (s *ClientCredentialsSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	if s.token.Valid(s.delta) {
+		token := s.token
+		s.mu.Unlock()
+		return token, nil
+	}
+
+	call := s.inflight
+	if call == nil {
+		call = &tokenCall{done: make(chan struct{})}
+		s.inflight = call
+		go s.refresh(ctx, call)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.token, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Invalidate drops token from the cache, unless it was already replaced by a newer one.
+func (s *ClientCredentialsSource) Invalidate(token *Token) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = nil
+	}
+}
+
+// refresh runs detached from the cancellation of the caller that started it,
+// so one caller giving up does not fail the others waiting on the same call.
+func (s *ClientCredentialsSource) refresh(ctx context.Context, call *tokenCall) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), tokenFetchTimeout)
+	defer cancel()
+
+	token, err := s.fetch(ctx)
+
+	s.mu.Lock()
+	if err == nil {
+		s.token = token
+		s.delta = s.config.ExpiryDelta
+		if !token.Expiry.IsZero() {
+			// Short-lived tokens would otherwise never be reused.
+			s.delta = min(s.delta, time.Until(token.Expiry)/2)
+		}
+	}
+	s.inflight = nil
+	s.mu.Unlock()
+
+	call.token, call.err = token, err
+	close(call.done)
+}
+
+func (s *ClientCredentialsSource) fetch(ctx context.Context) (*Token, error) {
+	form := url.Values{}
+	for k, values := range s.config.EndpointParams {
+		form[k] = append([]string(nil), values...)
+	}
+	form.Set("grant_type", "client_credentials")
+	if len(s.config.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.config.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set(ContentType, "application/x-www-form-urlencoded")
+	req.Header.Set(Accept, "application/json")
+	req.SetBasicAuth(url.QueryEscape(s.config.ClientID), url.QueryEscape(s.config.ClientSecret))
+
+	resp, err := s.config.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch token: %w", err)
+	}
+	defer CloseResponse(resp)
+
+	body, err := readBody(resp.Body, 1<<20)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload struct {
+		AccessToken      string `json:"access_token"`
+		TokenType        string `json:"token_type"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil && IsSuccess(resp) {
+		return nil, fmt.Errorf("failed to unmarshal token response: %w", err)
+	}
+
+	if !IsSuccess(resp) || payload.AccessToken == "" {
+		if payload.Error != "" {
+			return nil, fmt.Errorf("token endpoint returned status %d: %s: %s", resp.StatusCode, payload.Error, payload.ErrorDescription)
+		}
+		return nil, fmt.Errorf("token endpoint returned status %d without an access token", resp.StatusCode)
+	}
+
+	token := &Token{AccessToken: payload.AccessToken, TokenType: payload.TokenType}
+	if payload.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(payload.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// BearerAuth sets the Authorization header from source. On a 401 it
+// invalidates the token, when source supports it, and retries once with a
+// fresh one.
+func BearerAuth(source TokenSource) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+
+			token, err := source.Token(ctx)
+			if err != nil {
+				closeRequestBody(req)
+				return nil, fmt.Errorf("failed to get token: %w", err)
+			}
+
+			// A streamed body cannot be sent twice, so it gets no 401 retry.
+			invalidator, canRefresh := source.(tokenInvalidator)
+			canRefresh = canRefresh && !isStreaming(req)
+
+			req = req.Clone(ctx)
+			if canRefresh {
+				if err := bufferBody(req); err != nil {
+					return nil, err
+				}
+			}
+			attempt, err := withToken(req, token)
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := next.RoundTrip(attempt)
+			if err != nil || resp.StatusCode != http.StatusUnauthorized || !canRefresh {
+				return resp, err
+			}
+
+			invalidator.Invalidate(token)
+			fresh, err := source.Token(ctx)
+			if err != nil || fresh.AccessToken == token.AccessToken {
+				// Nothing new to try, the 401 stands.
+				return resp, nil
+			}
+
+			retry, err := withToken(req, fresh)
+			if err != nil {
+				return resp, nil
+			}
+			drainBody(resp)
+			return next.RoundTrip(retry)
+		})
+	}
+}
+
+// withToken returns a copy of req with a fresh body and the Authorization header set.
+func withToken(req *http.Request, token *Token) (*http.Request, error) {
+	out := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		out.Body = body
+	}
+	out.Header.Set(Authorization, token.header())
+	return out, nil
+}
+
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
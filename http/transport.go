@@ -0,0 +1,194 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+type TransportConfig struct {
+	// Extra root CAs trusted on top of the system pool, as a PEM file or bytes.
+	CAFile string
+	CAPEM  []byte
+
+	// Client certificate for mTLS, as PEM files or bytes.
+	CertFile string
+	KeyFile  string
+	CertPEM  []byte
+	KeyPEM   []byte
+
+	ServerName         string // overrides the name used to verify the server certificate
+	MinTLSVersion      uint16 // default: tls.VersionTLS12
+	InsecureSkipVerify bool   // only for local development
+
+	ProxyURL     string // proxy for all requests (default: HTTP_PROXY, HTTPS_PROXY and NO_PROXY)
+	DisableProxy bool   // ignore proxies, including the environment
+
+	MaxIdleConns          int           // Idle connections kept across all hosts (default: 200)
+	MaxIdleConnsPerHost   int           // Idle connections kept per host (default: 100)
+	MaxConnsPerHost       int           // Limit on connections per host (default: 0 = unlimited)
+	IdleConnTimeout       time.Duration // default: 90s
+	DialTimeout           time.Duration // default: 5s
+	KeepAlive             time.Duration // default: 30s
+	TLSHandshakeTimeout   time.Duration // default: 5s
+	ResponseHeaderTimeout time.Duration // default: 0 = no limit besides Config.Timeout
+	ExpectContinueTimeout time.Duration // default: 1s
+
+	DisableHTTP2       bool
+	DisableCompression bool
+}
+
+// DefaultTransportConfig keeps many idle connections per host, which suits
+// high-QPS calls between internal services.
+func DefaultTransportConfig() *TransportConfig {
+	return &TransportConfig{
+		MinTLSVersion:         tls.VersionTLS12,
+		MaxIdleConns:          200,
+		MaxIdleConnsPerHost:   100,
+		IdleConnTimeout:       90 * time.Second,
+		DialTimeout:           5 * time.Second,
+		KeepAlive:             30 * time.Second,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+func (c TransportConfig) withDefaults() TransportConfig {
+	defaults := DefaultTransportConfig()
+	if c.MinTLSVersion == 0 {
+		c.MinTLSVersion = defaults.MinTLSVersion
+	}
+	if c.MaxIdleConns <= 0 {
+		c.MaxIdleConns = defaults.MaxIdleConns
+	}
+	if c.MaxIdleConnsPerHost <= 0 {
+		c.MaxIdleConnsPerHost = defaults.MaxIdleConnsPerHost
+	}
+	if c.IdleConnTimeout <= 0 {
+		c.IdleConnTimeout = defaults.IdleConnTimeout
+	}
+	if c.DialTimeout <= 0 {
+		c.DialTimeout = defaults.DialTimeout
+	}
+	if c.KeepAlive <= 0 {
+		c.KeepAlive = defaults.KeepAlive
+	}
+	if c.TLSHandshakeTimeout <= 0 {
+		c.TLSHandshakeTimeout = defaults.TLSHandshakeTimeout
+	}
+	if c.ExpectContinueTimeout <= 0 {
+		c.ExpectContinueTimeout = defaults.ExpectContinueTimeout
+	}
+	return c
+}
+
+// NewTransport builds an *http.Transport from config. It fails when a PEM
+// file cannot be read or parsed, or the proxy URL is invalid.
+func NewTransport(config TransportConfig) (*http.Transport, error) {
+	config = config.withDefaults()
+
+	tlsConfig, err := config.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := config.proxy()
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   config.DialTimeout,
+		KeepAlive: config.KeepAlive,
+	}
+
+	transport := &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           dialer.DialContext,
+		TLSClientConfig:       tlsConfig,
+		ForceAttemptHTTP2:     !config.DisableHTTP2,
+		MaxIdleConns:          config.MaxIdleConns,
+		MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       config.MaxConnsPerHost,
+		IdleConnTimeout:       config.IdleConnTimeout,
+		TLSHandshakeTimeout:   config.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: config.ResponseHeaderTimeout,
+		ExpectContinueTimeout: config.ExpectContinueTimeout,
+		DisableCompression:    config.DisableCompression,
+	}
+	if config.DisableHTTP2 {
+		// A non-nil empty map turns off the built-in HTTP/2 upgrade.
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+	return transport, nil
+}
+
+func (c TransportConfig) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         c.MinTLSVersion,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	caPEM, err := pemBytes(c.CAPEM, c.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	if len(caPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, errors.New("failed to parse CA bundle: no certificates found")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	certPEM, err := pemBytes(c.CertPEM, c.CertFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client certificate: %w", err)
+	}
+	keyPEM, err := pemBytes(c.KeyPEM, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client key: %w", err)
+	}
+	if len(certPEM) > 0 || len(keyPEM) > 0 {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+func (c TransportConfig) proxy() (func(*http.Request) (*url.URL, error), error) {
+	if c.DisableProxy {
+		return nil, nil
+	}
+	if c.ProxyURL == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	proxyURL, err := url.Parse(c.ProxyURL)
+	if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q", c.ProxyURL)
+	}
+	return http.ProxyURL(proxyURL), nil
+}
+
+// pemBytes prefers inline data over a file path.
+func pemBytes(data []byte, path string) ([]byte, error) {
+	if len(data) > 0 || path == "" {
+		return data, nil
+	}
+	return os.ReadFile(path)
+}
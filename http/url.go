@@ -0,0 +1,140 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrInvalidBaseURL is returned by URLBuilder for bases that are not absolute URLs.
+var ErrInvalidBaseURL = errors.New("invalid base URL")
+
+// URLBuilder builds a URL from a base, a path template and query parameters,
+// escaping every part. Query parameters already in the base are kept.
+//
+//	u, err := http.NewURL("https://api.example.com/v1?key=abc").
+//		Path("/users/{id}/files").
+//		Param("id", "42").
+//		Query("tag", "a b", "c&d").
+//		String()
+//	// https://api.example.com/v1/users/42/files?key=abc&tag=a+b&tag=c%26d
+type URLBuilder struct {
+	base   string
+	path   string
+	params map[string]string
+	query  url.Values
+}
+
+func NewURL(base string) *URLBuilder {
+	return &URLBuilder{base: base, params: make(map[string]string), query: url.Values{}}
+}
+
+// Path appends template to the base path. Segments written as {name} are
+// replaced with the escaped value set with Param, so a value containing "/"
+// stays a single segment. The values "." and ".." are rejected.
+func (b *URLBuilder) Path(template string) *URLBuilder {
+	b.path = template
+	return b
+}
+
+func (b *URLBuilder) Param(name, value string) *URLBuilder {
+	b.params[name] = value
+	return b
+}
+
+// Query adds values for key, so repeated calls produce repeated keys.
+func (b *URLBuilder) Query(key string, values ...string) *URLBuilder {
+	for _, v := range values {
+		b.query.Add(key, v)
+	}
+	return b
+}
+
+// Params adds one value per key from params.
+func (b *URLBuilder) Params(params map[string]string) *URLBuilder {
+	for k, v := range params {
+		b.query.Add(k, v)
+	}
+	return b
+}
+
+func (b *URLBuilder) URL() (*url.URL, error) {
+	u, err := url.Parse(b.base)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBaseURL, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("%w: %q is not absolute", ErrInvalidBaseURL, b.base)
+	}
+
+	if b.path != "" {
+		path, err := b.expandPath()
+		if err != nil {
+			return nil, err
+		}
+		escaped := strings.TrimSuffix(u.EscapedPath(), "/") + "/" + strings.TrimPrefix(path, "/")
+		if u.Path, err = url.PathUnescape(escaped); err != nil {
+			return nil, fmt.Errorf("failed to build path: %w", err)
+		}
+		u.RawPath = escaped
+	}
+
+	if len(b.query) > 0 {
+		q := u.Query()
+		for k, values := range b.query {
+			q[k] = append(q[k], values...)
+		}
+		u.RawQuery = q.Encode()
+	}
+	return u, nil
+}
+
+func (b *URLBuilder) String() (string, error) {
+	u, err := b.URL()
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// expandPath escapes the literal parts of the template as they are and
+// replaces {name} segments with their escaped parameter.
+func (b *URLBuilder) expandPath() (string, error) {
+	var out strings.Builder
+	rest := b.path
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			out.WriteString(escapePath(rest))
+			return out.String(), nil
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unclosed parameter in path %q", b.path)
+		}
+		end += start
+
+		name := rest[start+1 : end]
+		value, ok := b.params[name]
+		if !ok {
+			return "", fmt.Errorf("missing path parameter %q", name)
+		}
+		if value == "." || value == ".." {
+			// PathEscape keeps dots, so these would walk up the path.
+			return "", fmt.Errorf("invalid path parameter %q: %q", name, value)
+		}
+		out.WriteString(escapePath(rest[:start]))
+		out.WriteString(url.PathEscape(value))
+		rest = rest[end+1:]
+	}
+}
+
+// escapePath escapes each segment of a literal path, keeping the slashes.
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
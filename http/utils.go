@@ -6,8 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"sort"
-	"strings"
+	"net/url"
 )
 
 func JSONRequest(data interface{}) (io.Reader, error) {
@@ -70,23 +69,27 @@ func IsServerError(resp *http.Response) bool {
 	return resp.StatusCode >= 500 && resp.StatusCode < 600
 }
 
+// BuildURL adds params to the query of baseURL, keeping any already there.
+// Relative bases such as "/api/users" are supported; bases that fail to
+// parse are returned unchanged.
+//
+// Deprecated: use NewURL, which reports invalid bases and supports repeated
+// keys and path parameters.
 func BuildURL(baseURL string, params map[string]string) string {
 	if len(params) == 0 {
 		return baseURL
 	}
 
-	keys := make([]string, 0, len(params))
-	for k := range params {
-		keys = append(keys, k)
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return baseURL
 	}
-	sort.Strings(keys)
-
-	var queryParts []string
-	for _, key := range keys {
-		queryParts = append(queryParts, fmt.Sprintf("%s=%s", key, params[key]))
+	q := u.Query()
+	for k, v := range params {
+		q.Set(k, v)
 	}
-
-	return baseURL + "?" + strings.Join(queryParts, "&")
+	u.RawQuery = q.Encode()
+	return u.String()
 }
 
 func CloseResponse(resp *http.Response) {
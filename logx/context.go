@@ -0,0 +1,77 @@
+package logx
+
+import (
+	"context"
+	"maps"
+)
+
+type contextKey int
+
+const (
+	fieldsKey contextKey = iota
+	requestIDKey
+	traceIDKey
+)
+
+const (
+	RequestIDField = "request_id"
+	TraceIDField   = "trace_id"
+)
+
+// ContextWithFields returns a copy of ctx carrying fields merged over any
+// fields already stored in it. Every logger adds them to each entry.
+func ContextWithFields(ctx context.Context, fields map[string]any) context.Context {
+	merged := make(map[string]any)
+	if existing, ok := ctx.Value(fieldsKey).(map[string]any); ok {
+		maps.Copy(merged, existing)
+	}
+	maps.Copy(merged, fields)
+	return context.WithValue(ctx, fieldsKey, merged)
+}
+
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey, traceID)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+func TraceIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(traceIDKey).(string)
+	return id
+}
+
+// FieldsFromContext returns the fields, request ID and trace ID stored in ctx
+// as a new map that is safe to modify.
+func FieldsFromContext(ctx context.Context) map[string]any {
+	fields := make(map[string]any)
+	if ctx == nil {
+		return fields
+	}
+
+	if f, ok := ctx.Value(fieldsKey).(map[string]any); ok {
+		maps.Copy(fields, f)
+	}
+
+	if reqID := RequestIDFromContext(ctx); reqID != "" {
+		fields[RequestIDField] = reqID
+	}
+
+	if traceID := TraceIDFromContext(ctx); traceID != "" {
+		fields[TraceIDField] = traceID
+	}
+
+	return fields
+}
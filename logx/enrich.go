@@ -0,0 +1,187 @@
+package logx
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const (
+	CallerField      = "caller"
+	StackTraceField  = "stacktrace"
+	ErrorChainSuffix = ".chain"
+)
+
+// EnrichConfig controls the extra fields every backend adds to an entry.
+// Independently of it, the first error passed as a printf argument is
+// always added as an "error" field, and errors that wrap other errors get
+// an "error.chain" field listing each message of the %w chain.
+type EnrichConfig struct {
+	Caller     bool // add the file:line of the code that called the logger
+	CallerSkip int  // extra frames to skip, for helpers that wrap a Logx
+	StackTrace bool // add a stack trace to Error and Fatal entries
+}
+
+// Enricher is implemented by the backends whose enrichment can be changed.
+type Enricher interface {
+	SetEnrich(config EnrichConfig)
+}
+
+// logxPackage is the prefix of every function in this package. Frames from
+// it, and from log/slog for the slog bridge, are skipped when looking for
+// the caller so the result is correct through With, wrappers and adapters.
+const logxPackage = "github.com/vixyninja/go-blocks/logx."
+
+// fields returns the enrichment for an entry. args are the printf arguments
+// and fields the structured fields of the call.
+func (c EnrichConfig) fields(level LogLevel, args []any, fields []Field) []Field {
+	var extra []Field
+
+	hasError := false
+	for _, f := range fields {
+		if f.Type != FieldTypeError || f.skip() {
+			continue
+		}
+		if f.Key == ErrorFieldKey {
+			hasError = true
+		}
+		if err, ok := f.Interface.(error); ok {
+			extra = appendErrorChain(extra, f.Key, err)
+		}
+	}
+
+	if !hasError {
+		for _, arg := range args {
+			if err, ok := arg.(error); ok && err != nil {
+				extra = append(extra, Err(err))
+				extra = appendErrorChain(extra, ErrorFieldKey, err)
+				break
+			}
+		}
+	}
+
+	if c.Caller {
+		if caller := callerLocation(c.CallerSkip); caller != "" {
+			extra = append(extra, String(CallerField, caller))
+		}
+	}
+
+	if c.StackTrace && level >= LevelError {
+		extra = append(extra, String(StackTraceField, stackTrace(c.CallerSkip)))
+	}
+
+	return extra
+}
+
+func appendErrorChain(fields []Field, key string, err error) []Field {
+	chain := errorChain(err)
+	if len(chain) < 2 {
+		return fields
+	}
+	return append(fields, Any(key+ErrorChainSuffix, chain))
+}
+
+// errorChain lists the messages of err and everything it wraps, following
+// both Unwrap() error and Unwrap() []error.
+func errorChain(err error) []string {
+	var chain []string
+	var walk func(error)
+	walk = func(e error) {
+		for e != nil {
+			chain = append(chain, e.Error())
+			if joined, ok := e.(interface{ Unwrap() []error }); ok {
+				for _, inner := range joined.Unwrap() {
+					walk(inner)
+				}
+				return
+			}
+			e = errors.Unwrap(e)
+		}
+	}
+	walk(err)
+	return chain
+}
+
+func isInternalFrame(function string) bool {
+	return strings.HasPrefix(function, logxPackage) || strings.HasPrefix(function, "log/slog.")
+}
+
+// callerFrames returns up to max frames starting at the first frame outside
+// logx, after skipping skip more. max <= 0 means the whole stack.
+func callerFrames(skip, max int) []runtime.Frame {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var out []runtime.Frame
+	found := false
+	for {
+		frame, more := frames.Next()
+		if !found && !isInternalFrame(frame.Function) {
+			if skip <= 0 {
+				found = true
+			} else {
+				skip--
+			}
+		}
+		if found {
+			out = append(out, frame)
+			if max > 0 && len(out) == max {
+				return out
+			}
+		}
+		if !more {
+			return out
+		}
+	}
+}
+
+// callerLocation returns "dir/file.go:line" of the first frame outside logx.
+func callerLocation(skip int) string {
+	frames := callerFrames(skip, 1)
+	if len(frames) == 0 {
+		return ""
+	}
+	return shortPath(frames[0].File) + ":" + strconv.Itoa(frames[0].Line)
+}
+
+func stackTrace(skip int) string {
+	var b strings.Builder
+	for i, frame := range callerFrames(skip, 0) {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(frame.Function)
+		b.WriteString("\n\t")
+		b.WriteString(frame.File)
+		b.WriteString(":")
+		b.WriteString(strconv.Itoa(frame.Line))
+	}
+	return b.String()
+}
+
+// shortPath keeps the last directory and the file name, like zap's short caller.
+func shortPath(path string) string {
+	idx := strings.LastIndexByte(path, '/')
+	if idx == -1 {
+		return path
+	}
+	idx = strings.LastIndexByte(path[:idx], '/')
+	if idx == -1 {
+		return path
+	}
+	return path[idx+1:]
+}
+
+// apply returns fields followed by their enrichment in a new slice.
+func (c EnrichConfig) apply(level LogLevel, args []any, fields []Field) []Field {
+	extra := c.fields(level, args, fields)
+	if len(extra) == 0 {
+		return fields
+	}
+
+	all := make([]Field, 0, len(fields)+len(extra))
+	all = append(all, fields...)
+	return append(all, extra...)
+}
@@ -1,13 +1,26 @@
 package logx
 
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
 type LoggerType string
 type Format string
 
 type LoggerConfig struct {
-	Type   LoggerType
-	Format Format // "json" or "text"
-	Output string // "stdout", "stderr", or file path
-	Prefix string // for std logger
+	Type     LoggerType
+	Format   Format          // "json" or "text"
+	Output   string          // "stdout", "stderr", or file path (default: stdout)
+	Rotation *RotationConfig // rotate the Output file with lumberjack, ignored for stdout/stderr
+	Prefix   string          // for std logger
+	Level    LogLevel        // minimum level, adjustable later via SetLevel (default: debug)
+	Sampling *SamplingConfig // sample repeated messages, nil disables sampling
+	Enrich   *EnrichConfig   // caller and stack trace fields, nil keeps the backend default
+	Redact   *RedactConfig   // mask sensitive fields, nil disables redaction
+	Sinks    []LoggerConfig  // fan out to several loggers, see below
 }
 
 const (
@@ -15,6 +28,7 @@ const (
 	LoggerTypeLogrus  LoggerType = "logrus"
 	LoggerTypeZap     LoggerType = "zap"
 	LoggerTypeZerolog LoggerType = "zerolog"
+	LoggerTypeSlog    LoggerType = "slog"
 )
 
 const (
@@ -53,34 +67,129 @@ func DefaultRotationConfig() *RotationConfig {
 	}
 }
 
+// NewLogger builds a logger from config. Outputs that cannot be opened,
+// including those of Sinks, are replaced by stdout and redaction patterns
+// that do not compile are skipped; both are reported on stderr. Use
+// BuildLogger to handle the error yourself. It never returns nil.
 func NewLogger(config LoggerConfig) Logx {
+	logger, err := build(config, true)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	if logger == nil {
+		return NewStdLoggerWithWriter(os.Stdout, "")
+	}
+	return logger
+}
+
+// BuildLogger is like NewLogger but returns an error when the output cannot be opened.
+//
+// When Sinks is set every sink is built from its own config and the result
+// is a MultiLogger; only Redact and Sampling of the outer config are used then.
+func BuildLogger(config LoggerConfig) (Logx, error) {
+	return build(config, false)
+}
+
+// build builds config. With fallback set, failing outputs are replaced by
+// stdout, bad redaction patterns are skipped and the errors are returned
+// along with a usable logger.
+func build(config LoggerConfig, fallback bool) (Logx, error) {
+	if len(config.Sinks) > 0 {
+		return buildMulti(config, fallback)
+	}
+
+	var errs []error
+	w, err := openOutput(config.Output, config.Rotation)
+	if err != nil {
+		err = fmt.Errorf("[pkg.logx.BuildLogger] %w", err)
+		if !fallback {
+			return nil, err
+		}
+		errs = append(errs, fmt.Errorf("%w, falling back to stdout", err))
+		config.Output = OutputStdout
+		w = os.Stdout
+	}
+
+	logger := newBackend(config, w, isConsole(config.Output))
+	logger.SetLevel(config.Level)
+
+	if config.Enrich != nil {
+		if e, ok := logger.(Enricher); ok {
+			e.SetEnrich(*config.Enrich)
+		}
+	}
+
+	logger, err = wrapLogger(logger, config, fallback)
+	return logger, errors.Join(append(errs, err)...)
+}
+
+func buildMulti(config LoggerConfig, fallback bool) (Logx, error) {
+	var errs []error
+	loggers := make([]Logx, 0, len(config.Sinks))
+	for _, sink := range config.Sinks {
+		logger, err := build(sink, fallback)
+		if err != nil {
+			if !fallback {
+				return nil, err
+			}
+			errs = append(errs, err)
+		}
+		if logger != nil {
+			loggers = append(loggers, logger)
+		}
+	}
+
+	logger, err := wrapLogger(NewMulti(loggers...), config, fallback)
+	return logger, errors.Join(append(errs, err)...)
+}
+
+// wrapLogger applies redaction and then sampling, so sampled entries are
+// redacted too. With fallback set, a redaction config whose patterns do not
+// compile is applied without them and the error is returned with the logger.
+func wrapLogger(logger Logx, config LoggerConfig, fallback bool) (Logx, error) {
+	var wrapErr error
+	if config.Redact != nil {
+		redacted, err := WithRedaction(logger, *config.Redact)
+		if err != nil {
+			if !fallback {
+				return nil, fmt.Errorf("[pkg.logx.BuildLogger] %w", err)
+			}
+			wrapErr = fmt.Errorf("[pkg.logx.BuildLogger] %w, ignoring redaction patterns", err)
+
+			withoutPatterns := *config.Redact
+			withoutPatterns.Patterns = nil
+			redacted, err = WithRedaction(logger, withoutPatterns)
+		}
+		if err == nil {
+			logger = redacted
+		}
+	}
+
+	if config.Sampling != nil {
+		logger = WithSampling(logger, *config.Sampling)
+	}
+	return logger, wrapErr
+}
+
+func newBackend(config LoggerConfig, w io.Writer, color bool) Logx {
 	switch config.Type {
 	case LoggerTypeStd:
-		if config.Prefix != "" {
-			return NewStdLoggerWithPrefix(config.Prefix)
-		}
-		return NewStdLogger()
+		return NewStdLoggerWithWriter(w, config.Prefix)
 
 	case LoggerTypeLogrus:
-		if config.Format == FormatJSON {
-			return NewLogrusJSONLogger()
-		}
-		return NewLogrusTextLogger()
+		return NewLogrusLoggerWithWriter(w, config.Format)
 
 	case LoggerTypeZap:
-		if config.Format == FormatJSON {
-			return NewZapJSONLogger()
-		}
-		return NewZapConsoleLogger()
+		return newZapLoggerWithWriter(w, config.Format, color)
 
 	case LoggerTypeZerolog:
-		if config.Format == FormatJSON {
-			return NewZerologJSONLogger()
-		}
-		return NewZerologConsoleLogger()
+		return newZerologLoggerWithWriter(w, config.Format, color)
+
+	case LoggerTypeSlog:
+		return NewSlogLoggerWithWriter(w, config.Format)
 
 	default:
-		return NewStdLogger()
+		return NewStdLoggerWithWriter(w, config.Prefix)
 	}
 }
 
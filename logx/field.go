@@ -0,0 +1,117 @@
+package logx
+
+import (
+	"time"
+)
+
+type FieldType uint8
+
+const (
+	FieldTypeAny FieldType = iota
+	FieldTypeString
+	FieldTypeInt64
+	FieldTypeFloat64
+	FieldTypeBool
+	FieldTypeDuration
+	FieldTypeTime
+	FieldTypeError
+)
+
+// ErrorFieldKey is the key used by Err.
+const ErrorFieldKey = "error"
+
+// Field is a typed key-value pair for the structured *w logging methods.
+// Backends map each type onto their native field so no reflection or
+// formatting happens for the common cases.
+type Field struct {
+	Key       string
+	Type      FieldType
+	Integer   int64
+	Float     float64
+	Str       string
+	Interface any
+}
+
+func String(key, value string) Field {
+	return Field{Key: key, Type: FieldTypeString, Str: value}
+}
+
+func Int(key string, value int) Field {
+	return Field{Key: key, Type: FieldTypeInt64, Integer: int64(value)}
+}
+
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Type: FieldTypeInt64, Integer: value}
+}
+
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Type: FieldTypeFloat64, Float: value}
+}
+
+func Bool(key string, value bool) Field {
+	var i int64
+	if value {
+		i = 1
+	}
+	return Field{Key: key, Type: FieldTypeBool, Integer: i}
+}
+
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Type: FieldTypeDuration, Integer: int64(value)}
+}
+
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, Type: FieldTypeTime, Interface: value}
+}
+
+// Err stores err under the "error" key. A nil error yields a field that is skipped.
+func Err(err error) Field {
+	return NamedErr(ErrorFieldKey, err)
+}
+
+func NamedErr(key string, err error) Field {
+	return Field{Key: key, Type: FieldTypeError, Interface: err}
+}
+
+func Any(key string, value any) Field {
+	return Field{Key: key, Type: FieldTypeAny, Interface: value}
+}
+
+// Value returns the field value as a plain Go value, errors become their message.
+func (f Field) Value() any {
+	switch f.Type {
+	case FieldTypeString:
+		return f.Str
+	case FieldTypeInt64:
+		return f.Integer
+	case FieldTypeFloat64:
+		return f.Float
+	case FieldTypeBool:
+		return f.Integer == 1
+	case FieldTypeDuration:
+		return time.Duration(f.Integer)
+	case FieldTypeError:
+		if err, ok := f.Interface.(error); ok && err != nil {
+			return err.Error()
+		}
+		return nil
+	default:
+		return f.Interface
+	}
+}
+
+func (f Field) skip() bool {
+	return f.Type == FieldTypeError && f.Interface == nil
+}
+
+// fieldsToMap converts typed fields into the map form used by With.
+func fieldsToMap(fields []Field) map[string]any {
+	m := make(map[string]any, len(fields))
+	for _, f := range fields {
+		if f.skip() {
+			continue
+		}
+		m[f.Key] = f.Value()
+	}
+	return m
+}
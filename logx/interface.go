@@ -9,6 +9,16 @@ type Logx interface {
 	Error(c context.Context, msg string, args ...any)
 	Fatal(c context.Context, msg string, args ...any)
 
+	// Structured variants: msg is logged as is and fields are emitted as native backend fields
+	Debugw(c context.Context, msg string, fields ...Field)
+	Infow(c context.Context, msg string, fields ...Field)
+	Warnw(c context.Context, msg string, fields ...Field)
+	Errorw(c context.Context, msg string, fields ...Field)
+	Fatalw(c context.Context, msg string, fields ...Field)
+
 	// REMAINING: This is for cloning the logger and adding fields to it
 	With(c context.Context, fields map[string]any) Logx
+
+	// SetLevel and Level adjust the minimum level at runtime, see Leveler
+	Leveler
 }
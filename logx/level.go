@@ -0,0 +1,95 @@
+package logx
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+type LogLevel int
+
+const (
+	LevelDebug LogLevel = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+	LevelFatal
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	case LevelFatal:
+		return "FATAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ParseLevel converts a level name such as "debug" or "WARN" into a LogLevel.
+func ParseLevel(s string) (LogLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug", "":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	case "fatal":
+		return LevelFatal, nil
+	default:
+		return LevelDebug, fmt.Errorf("[pkg.logx.ParseLevel] unknown log level: %q", s)
+	}
+}
+
+func (l LogLevel) MarshalText() ([]byte, error) {
+	return []byte(strings.ToLower(l.String())), nil
+}
+
+func (l *LogLevel) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+// Leveler is implemented by loggers whose minimum level can be changed at runtime.
+// Loggers derived through With share the level of their parent.
+type Leveler interface {
+	SetLevel(level LogLevel)
+	Level() LogLevel
+}
+
+// levelVar is a concurrency-safe minimum level shared by a logger and its children.
+type levelVar struct {
+	v atomic.Int32
+}
+
+func newLevelVar(level LogLevel) *levelVar {
+	lv := &levelVar{}
+	lv.Set(level)
+	return lv
+}
+
+func (lv *levelVar) Set(level LogLevel) {
+	lv.v.Store(int32(level))
+}
+
+func (lv *levelVar) Level() LogLevel {
+	return LogLevel(lv.v.Load())
+}
+
+func (lv *levelVar) Enabled(level LogLevel) bool {
+	return level >= lv.Level()
+}
@@ -5,15 +5,14 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
 
 	"github.com/sirupsen/logrus"
-	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 type LogrusLogger struct {
 	logger *logrus.Logger
 	fields map[string]any
+	enrich EnrichConfig
 }
 
 type LogrusConfig struct {
@@ -101,10 +100,39 @@ func (l *LogrusLogger) Error(ctx context.Context, msg string, args ...any) {
 }
 
 func (l *LogrusLogger) Fatal(ctx context.Context, msg string, args ...any) {
-	l.log(ctx, logrus.FatalLevel, msg, args...)
+	l.fatal(ctx, msg, args...)
 	os.Exit(1)
 }
 
+func (l *LogrusLogger) Debugw(ctx context.Context, msg string, fields ...Field) {
+	l.logw(ctx, logrus.DebugLevel, msg, fields)
+}
+
+func (l *LogrusLogger) Infow(ctx context.Context, msg string, fields ...Field) {
+	l.logw(ctx, logrus.InfoLevel, msg, fields)
+}
+
+func (l *LogrusLogger) Warnw(ctx context.Context, msg string, fields ...Field) {
+	l.logw(ctx, logrus.WarnLevel, msg, fields)
+}
+
+func (l *LogrusLogger) Errorw(ctx context.Context, msg string, fields ...Field) {
+	l.logw(ctx, logrus.ErrorLevel, msg, fields)
+}
+
+func (l *LogrusLogger) Fatalw(ctx context.Context, msg string, fields ...Field) {
+	l.fatalw(ctx, msg, fields...)
+	os.Exit(1)
+}
+
+func (l *LogrusLogger) fatal(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, logrus.FatalLevel, msg, args...)
+}
+
+func (l *LogrusLogger) fatalw(ctx context.Context, msg string, fields ...Field) {
+	l.logw(ctx, logrus.FatalLevel, msg, fields)
+}
+
 func (l *LogrusLogger) With(_ context.Context, fields map[string]any) Logx {
 	newFields := make(map[string]any)
 	for k, v := range l.fields {
@@ -117,15 +145,30 @@ func (l *LogrusLogger) With(_ context.Context, fields map[string]any) Logx {
 	return &LogrusLogger{
 		logger: l.logger,
 		fields: newFields,
+		enrich: l.enrich,
 	}
 }
 
+func (l *LogrusLogger) SetEnrich(config EnrichConfig) {
+	l.enrich = config
+}
+
+func (l *LogrusLogger) SetLevel(level LogLevel) {
+	l.logger.SetLevel(toLogrusLevel(level))
+}
+
+func (l *LogrusLogger) Level() LogLevel {
+	return fromLogrusLevel(l.logger.GetLevel())
+}
+
 func (l *LogrusLogger) log(ctx context.Context, level logrus.Level, msg string, args ...any) {
-	entry := l.logger.WithFields(logrus.Fields(l.fields))
+	if !l.logger.IsLevelEnabled(level) {
+		return
+	}
 
-	contextFields := extractContextFields(ctx)
-	if len(contextFields) > 0 {
-		entry = entry.WithFields(logrus.Fields(contextFields))
+	entry := l.entry(ctx)
+	if extra := l.enrich.fields(fromLogrusLevel(level), args, nil); len(extra) > 0 {
+		entry = entry.WithFields(toLogrusFields(extra))
 	}
 
 	if len(args) > 0 {
@@ -135,25 +178,57 @@ func (l *LogrusLogger) log(ctx context.Context, level logrus.Level, msg string,
 	}
 }
 
-func LogrusLoggerWithRotation(filePath string, rotConfig *RotationConfig, format Format) (*LogrusLogger, error) {
-	dir := filepath.Dir(filePath)
-	if err := os.MkdirAll(dir, 0700); err != nil {
-		return nil, fmt.Errorf("[pkg.logx.LogrusLoggerWithRotation] failed to create log directory: %w", err)
+func (l *LogrusLogger) logw(ctx context.Context, level logrus.Level, msg string, fields []Field) {
+	if !l.logger.IsLevelEnabled(level) {
+		return
 	}
 
-	if rotConfig == nil {
-		rotConfig = DefaultRotationConfig()
+	entry := l.entry(ctx)
+	fields = l.enrich.apply(fromLogrusLevel(level), nil, fields)
+	if len(fields) > 0 {
+		entry = entry.WithFields(toLogrusFields(fields))
 	}
 
-	lumberjackLogger := &lumberjack.Logger{
-		Filename:   filePath,
-		MaxSize:    rotConfig.MaxSize,
-		MaxBackups: rotConfig.MaxBackups,
-		MaxAge:     rotConfig.MaxAge,
-		Compress:   rotConfig.Compress,
-		LocalTime:  rotConfig.LocalTime,
+	entry.Log(level, msg)
+}
+
+func toLogrusFields(fields []Field) logrus.Fields {
+	data := make(logrus.Fields, len(fields))
+	for _, f := range fields {
+		if f.skip() {
+			continue
+		}
+		if f.Type == FieldTypeError {
+			// logrus formatters render error values themselves
+			data[f.Key] = f.Interface
+			continue
+		}
+		data[f.Key] = f.Value()
 	}
+	return data
+}
+
+func (l *LogrusLogger) entry(ctx context.Context) *logrus.Entry {
+	entry := l.logger.WithFields(logrus.Fields(l.fields))
+
+	contextFields := FieldsFromContext(ctx)
+	if len(contextFields) > 0 {
+		entry = entry.WithFields(logrus.Fields(contextFields))
+	}
+
+	return entry
+}
 
+func LogrusLoggerWithRotation(filePath string, rotConfig *RotationConfig, format Format) (*LogrusLogger, error) {
+	lumberjackLogger, err := newRotationWriter(filePath, rotConfig)
+	if err != nil {
+		return nil, fmt.Errorf("[pkg.logx.LogrusLoggerWithRotation] %w", err)
+	}
+
+	return NewLogrusLoggerWithWriter(lumberjackLogger, format), nil
+}
+
+func NewLogrusLoggerWithWriter(w io.Writer, format Format) *LogrusLogger {
 	var formatter logrus.Formatter
 	switch format {
 	case FormatJSON:
@@ -163,8 +238,40 @@ func LogrusLoggerWithRotation(filePath string, rotConfig *RotationConfig, format
 	}
 
 	config := LogrusConfig{
-		Output:    lumberjackLogger,
+		Output:    w,
 		Formatter: formatter,
 	}
-	return NewLogrusLoggerWithConfig(config), nil
+	return NewLogrusLoggerWithConfig(config)
+}
+
+func toLogrusLevel(level LogLevel) logrus.Level {
+	switch level {
+	case LevelDebug:
+		return logrus.DebugLevel
+	case LevelInfo:
+		return logrus.InfoLevel
+	case LevelWarn:
+		return logrus.WarnLevel
+	case LevelError:
+		return logrus.ErrorLevel
+	case LevelFatal:
+		return logrus.FatalLevel
+	default:
+		return logrus.DebugLevel
+	}
+}
+
+func fromLogrusLevel(level logrus.Level) LogLevel {
+	switch level {
+	case logrus.TraceLevel, logrus.DebugLevel:
+		return LevelDebug
+	case logrus.InfoLevel:
+		return LevelInfo
+	case logrus.WarnLevel:
+		return LevelWarn
+	case logrus.ErrorLevel:
+		return LevelError
+	default:
+		return LevelFatal
+	}
 }
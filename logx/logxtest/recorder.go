@@ -0,0 +1,238 @@
+// Package logxtest provides an in-memory logx.Logx for asserting on log output in tests.
+package logxtest
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/vixyninja/go-blocks/logx"
+)
+
+// Entry is a single recorded log call.
+type Entry struct {
+	Level         logx.LogLevel
+	Message       string         // formatted message
+	Template      string         // message before formatting
+	Args          []any          // printf arguments
+	Fields        map[string]any // With fields merged with structured fields
+	ContextFields map[string]any // fields found in the context, see logx.FieldsFromContext
+}
+
+// Field returns the value of key from Fields, falling back to ContextFields.
+func (e Entry) Field(key string) (any, bool) {
+	if v, ok := e.Fields[key]; ok {
+		return v, true
+	}
+	v, ok := e.ContextFields[key]
+	return v, ok
+}
+
+func (e Entry) String() string {
+	return fmt.Sprintf("[%s] %s %v %v", e.Level, e.Message, e.Fields, e.ContextFields)
+}
+
+// Recorder implements logx.Logx and keeps every entry in memory. Loggers
+// derived through With record into the same store. Fatal is recorded but
+// does not exit the process.
+type Recorder struct {
+	store  *store
+	fields map[string]any
+}
+
+type store struct {
+	mu      sync.Mutex
+	entries []Entry
+	level   atomic.Int32
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{
+		store:  &store{},
+		fields: make(map[string]any),
+	}
+}
+
+func (r *Recorder) Debug(ctx context.Context, msg string, args ...any) {
+	r.record(ctx, logx.LevelDebug, msg, args, nil)
+}
+
+func (r *Recorder) Info(ctx context.Context, msg string, args ...any) {
+	r.record(ctx, logx.LevelInfo, msg, args, nil)
+}
+
+func (r *Recorder) Warn(ctx context.Context, msg string, args ...any) {
+	r.record(ctx, logx.LevelWarn, msg, args, nil)
+}
+
+func (r *Recorder) Error(ctx context.Context, msg string, args ...any) {
+	r.record(ctx, logx.LevelError, msg, args, nil)
+}
+
+func (r *Recorder) Fatal(ctx context.Context, msg string, args ...any) {
+	r.record(ctx, logx.LevelFatal, msg, args, nil)
+}
+
+func (r *Recorder) Debugw(ctx context.Context, msg string, fields ...logx.Field) {
+	r.record(ctx, logx.LevelDebug, msg, nil, fields)
+}
+
+func (r *Recorder) Infow(ctx context.Context, msg string, fields ...logx.Field) {
+	r.record(ctx, logx.LevelInfo, msg, nil, fields)
+}
+
+func (r *Recorder) Warnw(ctx context.Context, msg string, fields ...logx.Field) {
+	r.record(ctx, logx.LevelWarn, msg, nil, fields)
+}
+
+func (r *Recorder) Errorw(ctx context.Context, msg string, fields ...logx.Field) {
+	r.record(ctx, logx.LevelError, msg, nil, fields)
+}
+
+func (r *Recorder) Fatalw(ctx context.Context, msg string, fields ...logx.Field) {
+	r.record(ctx, logx.LevelFatal, msg, nil, fields)
+}
+
+func (r *Recorder) With(_ context.Context, fields map[string]any) logx.Logx {
+	newFields := make(map[string]any)
+	maps.Copy(newFields, r.fields)
+	maps.Copy(newFields, fields)
+
+	return &Recorder{
+		store:  r.store,
+		fields: newFields,
+	}
+}
+
+func (r *Recorder) SetLevel(level logx.LogLevel) {
+	r.store.level.Store(int32(level))
+}
+
+func (r *Recorder) Level() logx.LogLevel {
+	return logx.LogLevel(r.store.level.Load())
+}
+
+func (r *Recorder) record(ctx context.Context, level logx.LogLevel, msg string, args []any, fields []logx.Field) {
+	if level < r.Level() {
+		return
+	}
+
+	formatted := msg
+	if len(args) > 0 {
+		formatted = fmt.Sprintf(msg, args...)
+	}
+
+	entryFields := make(map[string]any, len(r.fields)+len(fields))
+	maps.Copy(entryFields, r.fields)
+	for _, f := range fields {
+		entryFields[f.Key] = f.Value()
+	}
+
+	entry := Entry{
+		Level:         level,
+		Message:       formatted,
+		Template:      msg,
+		Args:          args,
+		Fields:        entryFields,
+		ContextFields: logx.FieldsFromContext(ctx),
+	}
+
+	r.store.mu.Lock()
+	r.store.entries = append(r.store.entries, entry)
+	r.store.mu.Unlock()
+}
+
+// Entries returns a copy of all recorded entries in call order.
+func (r *Recorder) Entries() []Entry {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	entries := make([]Entry, len(r.store.entries))
+	copy(entries, r.store.entries)
+	return entries
+}
+
+func (r *Recorder) Len() int {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return len(r.store.entries)
+}
+
+func (r *Recorder) Reset() {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.entries = nil
+}
+
+// Filter returns the entries for which match returns true.
+func (r *Recorder) Filter(match func(Entry) bool) []Entry {
+	var out []Entry
+	for _, e := range r.Entries() {
+		if match(e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func (r *Recorder) FilterLevel(level logx.LogLevel) []Entry {
+	return r.Filter(func(e Entry) bool { return e.Level == level })
+}
+
+func (r *Recorder) FilterMessage(substr string) []Entry {
+	return r.Filter(func(e Entry) bool { return strings.Contains(e.Message, substr) })
+}
+
+func (r *Recorder) FilterField(key string, value any) []Entry {
+	return r.Filter(func(e Entry) bool {
+		v, ok := e.Field(key)
+		return ok && reflect.DeepEqual(v, value)
+	})
+}
+
+// AssertContains fails t unless some entry's message contains substr.
+func (r *Recorder) AssertContains(t testing.TB, substr string) {
+	t.Helper()
+	if len(r.FilterMessage(substr)) == 0 {
+		t.Errorf("expected a log entry containing %q, got:\n%s", substr, r.dump())
+	}
+}
+
+// AssertNotContains fails t if any entry's message contains substr.
+func (r *Recorder) AssertNotContains(t testing.TB, substr string) {
+	t.Helper()
+	if len(r.FilterMessage(substr)) > 0 {
+		t.Errorf("expected no log entry containing %q, got:\n%s", substr, r.dump())
+	}
+}
+
+// AssertLogged fails t unless an entry at level has a message containing substr.
+func (r *Recorder) AssertLogged(t testing.TB, level logx.LogLevel, substr string) {
+	t.Helper()
+	matches := r.Filter(func(e Entry) bool {
+		return e.Level == level && strings.Contains(e.Message, substr)
+	})
+	if len(matches) == 0 {
+		t.Errorf("expected a %s entry containing %q, got:\n%s", level, substr, r.dump())
+	}
+}
+
+func (r *Recorder) dump() string {
+	entries := r.Entries()
+	if len(entries) == 0 {
+		return "  (no entries)"
+	}
+
+	var b strings.Builder
+	for _, e := range entries {
+		b.WriteString("  ")
+		b.WriteString(e.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,147 @@
+package logx
+
+import (
+	"context"
+	"os"
+)
+
+// fatalWriter writes a fatal entry without exiting, so wrappers can reach
+// every sink before the process exits. All backends in this package implement it.
+type fatalWriter interface {
+	fatal(ctx context.Context, msg string, args ...any)
+	fatalw(ctx context.Context, msg string, fields ...Field)
+}
+
+// MultiLogger sends every call to several loggers, each keeping its own
+// format, output and level.
+type MultiLogger struct {
+	loggers []Logx
+}
+
+// NewMulti returns a logger writing to all of loggers, for example a
+// coloured console at info level and a JSON rotated file at debug.
+func NewMulti(loggers ...Logx) *MultiLogger {
+	return &MultiLogger{loggers: loggers}
+}
+
+func (m *MultiLogger) Debug(ctx context.Context, msg string, args ...any) {
+	for _, l := range m.loggers {
+		l.Debug(ctx, msg, args...)
+	}
+}
+
+func (m *MultiLogger) Info(ctx context.Context, msg string, args ...any) {
+	for _, l := range m.loggers {
+		l.Info(ctx, msg, args...)
+	}
+}
+
+func (m *MultiLogger) Warn(ctx context.Context, msg string, args ...any) {
+	for _, l := range m.loggers {
+		l.Warn(ctx, msg, args...)
+	}
+}
+
+func (m *MultiLogger) Error(ctx context.Context, msg string, args ...any) {
+	for _, l := range m.loggers {
+		l.Error(ctx, msg, args...)
+	}
+}
+
+func (m *MultiLogger) Fatal(ctx context.Context, msg string, args ...any) {
+	m.fatal(ctx, msg, args...)
+	os.Exit(1)
+}
+
+func (m *MultiLogger) Debugw(ctx context.Context, msg string, fields ...Field) {
+	for _, l := range m.loggers {
+		l.Debugw(ctx, msg, fields...)
+	}
+}
+
+func (m *MultiLogger) Infow(ctx context.Context, msg string, fields ...Field) {
+	for _, l := range m.loggers {
+		l.Infow(ctx, msg, fields...)
+	}
+}
+
+func (m *MultiLogger) Warnw(ctx context.Context, msg string, fields ...Field) {
+	for _, l := range m.loggers {
+		l.Warnw(ctx, msg, fields...)
+	}
+}
+
+func (m *MultiLogger) Errorw(ctx context.Context, msg string, fields ...Field) {
+	for _, l := range m.loggers {
+		l.Errorw(ctx, msg, fields...)
+	}
+}
+
+func (m *MultiLogger) Fatalw(ctx context.Context, msg string, fields ...Field) {
+	m.fatalw(ctx, msg, fields...)
+	os.Exit(1)
+}
+
+func (m *MultiLogger) With(ctx context.Context, fields map[string]any) Logx {
+	loggers := make([]Logx, len(m.loggers))
+	for i, l := range m.loggers {
+		loggers[i] = l.With(ctx, fields)
+	}
+	return &MultiLogger{loggers: loggers}
+}
+
+// SetLevel sets the same level on every sink.
+func (m *MultiLogger) SetLevel(level LogLevel) {
+	for _, l := range m.loggers {
+		l.SetLevel(level)
+	}
+}
+
+// Level returns the most verbose level among the sinks.
+func (m *MultiLogger) Level() LogLevel {
+	level := LevelFatal
+	for _, l := range m.loggers {
+		if lv := l.Level(); lv < level {
+			level = lv
+		}
+	}
+	return level
+}
+
+func (m *MultiLogger) SetEnrich(config EnrichConfig) {
+	for _, l := range m.loggers {
+		if e, ok := l.(Enricher); ok {
+			e.SetEnrich(config)
+		}
+	}
+}
+
+// fatal writes to the sinks that can log without exiting first, then hands
+// over to the first foreign sink, whose Fatal is expected to exit.
+func (m *MultiLogger) fatal(ctx context.Context, msg string, args ...any) {
+	var foreign []Logx
+	for _, l := range m.loggers {
+		if fw, ok := l.(fatalWriter); ok {
+			fw.fatal(ctx, msg, args...)
+		} else {
+			foreign = append(foreign, l)
+		}
+	}
+	for _, l := range foreign {
+		l.Fatal(ctx, msg, args...)
+	}
+}
+
+func (m *MultiLogger) fatalw(ctx context.Context, msg string, fields ...Field) {
+	var foreign []Logx
+	for _, l := range m.loggers {
+		if fw, ok := l.(fatalWriter); ok {
+			fw.fatalw(ctx, msg, fields...)
+		} else {
+			foreign = append(foreign, l)
+		}
+	}
+	for _, l := range foreign {
+		l.Fatalw(ctx, msg, fields...)
+	}
+}
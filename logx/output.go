@@ -0,0 +1,64 @@
+package logx
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+)
+
+// openOutput resolves LoggerConfig.Output into a writer. Empty means stdout,
+// anything other than "stdout"/"stderr" is treated as a file path which is
+// rotated when rotation is non-nil and appended to otherwise.
+func openOutput(output string, rotation *RotationConfig) (io.Writer, error) {
+	switch output {
+	case "", OutputStdout:
+		return os.Stdout, nil
+	case OutputStderr:
+		return os.Stderr, nil
+	}
+
+	if rotation != nil {
+		return newRotationWriter(output, rotation)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(output), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	return file, nil
+}
+
+func newRotationWriter(filePath string, rotConfig *RotationConfig) (*lumberjack.Logger, error) {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	if rotConfig == nil {
+		rotConfig = DefaultRotationConfig()
+	}
+
+	return &lumberjack.Logger{
+		Filename:   filePath,
+		MaxSize:    rotConfig.MaxSize,
+		MaxBackups: rotConfig.MaxBackups,
+		MaxAge:     rotConfig.MaxAge,
+		Compress:   rotConfig.Compress,
+		LocalTime:  rotConfig.LocalTime,
+	}, nil
+}
+
+func isConsole(output string) bool {
+	return output == "" || output == OutputStdout || output == OutputStderr
+}
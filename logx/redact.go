@@ -0,0 +1,451 @@
+package logx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// DefaultRedactMask replaces redacted values when RedactConfig.Mask is empty.
+const DefaultRedactMask = "[REDACTED]"
+
+type RedactConfig struct {
+	Keys      []string   // field names to mask, case-insensitive, matched at any depth
+	Patterns  []string   // regular expressions, matches inside string values are masked
+	Redactors []Redactor // custom rules, run after Keys and before Patterns
+	Mask      string     // replacement text (default: DefaultRedactMask)
+}
+
+// CardNumberPattern matches card numbers of 13 to 19 digits, optionally
+// grouped with spaces or dashes. Matches are only masked when they pass the
+// Luhn check, so timestamps and numeric IDs of the same length are kept.
+const CardNumberPattern = `\b(?:\d[ -]?){12,18}\d\b`
+
+// DefaultRedactConfig masks common credentials and card numbers.
+func DefaultRedactConfig() *RedactConfig {
+	return &RedactConfig{
+		Keys: []string{
+			"password", "passwd", "secret", "authorization", "cookie", "set-cookie",
+			"token", "access_token", "refresh_token", "id_token", "api_key", "apikey",
+			"client_secret", "private_key",
+		},
+		Patterns: []string{CardNumberPattern},
+	}
+}
+
+// Redactor is a custom redaction rule. Redact is called for every key and
+// value, nested ones included, and returns the value to log and whether it
+// was replaced.
+type Redactor interface {
+	Redact(key string, value any) (any, bool)
+}
+
+// RedactorFunc adapts a function to the Redactor interface.
+type RedactorFunc func(key string, value any) (any, bool)
+
+func (f RedactorFunc) Redact(key string, value any) (any, bool) {
+	return f(key, value)
+}
+
+// RedactedLogger wraps a Logx and masks sensitive data in With fields,
+// structured fields, context fields and string printf arguments before they
+// reach the backend.
+type RedactedLogger struct {
+	logger   Logx
+	redactor *redactor
+}
+
+// WithRedaction wraps logger with the rules of config. It fails when a pattern does not compile.
+func WithRedaction(logger Logx, config RedactConfig) (*RedactedLogger, error) {
+	r, err := newRedactor(config)
+	if err != nil {
+		return nil, fmt.Errorf("[pkg.logx.WithRedaction] %w", err)
+	}
+	return &RedactedLogger{logger: logger, redactor: r}, nil
+}
+
+func (l *RedactedLogger) Debug(ctx context.Context, msg string, args ...any) {
+	if l.enabled(LevelDebug) {
+		l.logger.Debug(l.redactor.context(ctx), msg, l.redactor.args(args)...)
+	}
+}
+
+func (l *RedactedLogger) Info(ctx context.Context, msg string, args ...any) {
+	if l.enabled(LevelInfo) {
+		l.logger.Info(l.redactor.context(ctx), msg, l.redactor.args(args)...)
+	}
+}
+
+func (l *RedactedLogger) Warn(ctx context.Context, msg string, args ...any) {
+	if l.enabled(LevelWarn) {
+		l.logger.Warn(l.redactor.context(ctx), msg, l.redactor.args(args)...)
+	}
+}
+
+func (l *RedactedLogger) Error(ctx context.Context, msg string, args ...any) {
+	if l.enabled(LevelError) {
+		l.logger.Error(l.redactor.context(ctx), msg, l.redactor.args(args)...)
+	}
+}
+
+func (l *RedactedLogger) Fatal(ctx context.Context, msg string, args ...any) {
+	l.fatal(ctx, msg, args...)
+	os.Exit(1)
+}
+
+func (l *RedactedLogger) Debugw(ctx context.Context, msg string, fields ...Field) {
+	if l.enabled(LevelDebug) {
+		l.logger.Debugw(l.redactor.context(ctx), msg, l.redactor.fields(fields)...)
+	}
+}
+
+func (l *RedactedLogger) Infow(ctx context.Context, msg string, fields ...Field) {
+	if l.enabled(LevelInfo) {
+		l.logger.Infow(l.redactor.context(ctx), msg, l.redactor.fields(fields)...)
+	}
+}
+
+func (l *RedactedLogger) Warnw(ctx context.Context, msg string, fields ...Field) {
+	if l.enabled(LevelWarn) {
+		l.logger.Warnw(l.redactor.context(ctx), msg, l.redactor.fields(fields)...)
+	}
+}
+
+func (l *RedactedLogger) Errorw(ctx context.Context, msg string, fields ...Field) {
+	if l.enabled(LevelError) {
+		l.logger.Errorw(l.redactor.context(ctx), msg, l.redactor.fields(fields)...)
+	}
+}
+
+func (l *RedactedLogger) Fatalw(ctx context.Context, msg string, fields ...Field) {
+	l.fatalw(ctx, msg, fields...)
+	os.Exit(1)
+}
+
+func (l *RedactedLogger) fatal(ctx context.Context, msg string, args ...any) {
+	ctx, args = l.redactor.context(ctx), l.redactor.args(args)
+	if fw, ok := l.logger.(fatalWriter); ok {
+		fw.fatal(ctx, msg, args...)
+		return
+	}
+	l.logger.Fatal(ctx, msg, args...)
+}
+
+func (l *RedactedLogger) fatalw(ctx context.Context, msg string, fields ...Field) {
+	ctx, fields = l.redactor.context(ctx), l.redactor.fields(fields)
+	if fw, ok := l.logger.(fatalWriter); ok {
+		fw.fatalw(ctx, msg, fields...)
+		return
+	}
+	l.logger.Fatalw(ctx, msg, fields...)
+}
+
+func (l *RedactedLogger) With(ctx context.Context, fields map[string]any) Logx {
+	return &RedactedLogger{
+		logger:   l.logger.With(l.redactor.context(ctx), l.redactor.mapValues(fields)),
+		redactor: l.redactor,
+	}
+}
+
+func (l *RedactedLogger) SetLevel(level LogLevel) {
+	l.logger.SetLevel(level)
+}
+
+func (l *RedactedLogger) Level() LogLevel {
+	return l.logger.Level()
+}
+
+func (l *RedactedLogger) enabled(level LogLevel) bool {
+	return level >= l.logger.Level()
+}
+
+type redactor struct {
+	keys      map[string]struct{}
+	patterns  []redactPattern
+	redactors []Redactor
+	mask      string
+}
+
+func newRedactor(config RedactConfig) (*redactor, error) {
+	r := &redactor{
+		keys:      make(map[string]struct{}, len(config.Keys)),
+		redactors: config.Redactors,
+		mask:      config.Mask,
+	}
+	if r.mask == "" {
+		r.mask = DefaultRedactMask
+	}
+
+	for _, key := range config.Keys {
+		r.keys[strings.ToLower(key)] = struct{}{}
+	}
+
+	for _, pattern := range config.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
+		}
+		p := redactPattern{re: re}
+		if pattern == CardNumberPattern {
+			p.valid = luhnValid
+		}
+		r.patterns = append(r.patterns, p)
+	}
+
+	return r, nil
+}
+
+// sensitiveKey matches key, or its last segment for dotted keys such as the
+// ones produced by the slog bridge for groups.
+func (r *redactor) sensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	if _, ok := r.keys[key]; ok {
+		return true
+	}
+	if idx := strings.LastIndexByte(key, '.'); idx >= 0 {
+		_, ok := r.keys[key[idx+1:]]
+		return ok
+	}
+	return false
+}
+
+// redactPattern is a compiled pattern; valid, when set, must accept a
+// match for it to be masked.
+type redactPattern struct {
+	re    *regexp.Regexp
+	valid func(match string) bool
+}
+
+func (r *redactor) maskString(s string) (string, bool) {
+	changed := false
+	for _, p := range r.patterns {
+		if !p.re.MatchString(s) {
+			continue
+		}
+		if p.valid == nil {
+			s = p.re.ReplaceAllLiteralString(s, r.mask)
+			changed = true
+			continue
+		}
+		s = p.re.ReplaceAllStringFunc(s, func(match string) string {
+			if !p.valid(match) {
+				return match
+			}
+			changed = true
+			return r.mask
+		})
+	}
+	return s, changed
+}
+
+// maskError returns err with Patterns masked in its message and in every
+// error it wraps, so the error chain stays available without leaking.
+func (r *redactor) maskError(err error) (error, bool) {
+	msg, changed := r.maskString(err.Error())
+
+	var wrapped []error
+	switch u := err.(type) {
+	case interface{ Unwrap() []error }:
+		wrapped = u.Unwrap()
+	case interface{ Unwrap() error }:
+		if inner := u.Unwrap(); inner != nil {
+			wrapped = []error{inner}
+		}
+	}
+	masked := make([]error, len(wrapped))
+	for i, inner := range wrapped {
+		var ok bool
+		masked[i], ok = r.maskError(inner)
+		changed = changed || ok
+	}
+
+	if !changed {
+		return err, false
+	}
+	return &redactedError{msg: msg, wrapped: masked}, true
+}
+
+type redactedError struct {
+	msg     string
+	wrapped []error
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() []error {
+	return e.wrapped
+}
+
+// luhnValid reports whether the digits of s pass the Luhn checksum.
+func luhnValid(s string) bool {
+	sum, double := 0, false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// value returns the redacted form of value logged under key and whether it changed.
+func (r *redactor) value(key string, value any) (any, bool) {
+	if r.sensitiveKey(key) {
+		return r.mask, true
+	}
+
+	for _, custom := range r.redactors {
+		if v, ok := custom.Redact(key, value); ok {
+			return v, true
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return r.maskString(v)
+	case error:
+		return r.maskError(v)
+	case map[string]any:
+		return r.mapValues(v), true
+	case map[string]string:
+		out := make(map[string]any, len(v))
+		for k, inner := range v {
+			out[k], _ = r.value(k, inner)
+		}
+		return out, true
+	case []any:
+		out := make([]any, len(v))
+		for i, inner := range v {
+			out[i], _ = r.value(key, inner)
+		}
+		return out, true
+	}
+	return value, false
+}
+
+// mapValues returns a redacted copy of fields, nested maps included.
+func (r *redactor) mapValues(fields map[string]any) map[string]any {
+	if fields == nil {
+		return nil
+	}
+	out := make(map[string]any, len(fields))
+	for k, v := range fields {
+		out[k], _ = r.value(k, v)
+	}
+	return out
+}
+
+func (r *redactor) fields(fields []Field) []Field {
+	var out []Field
+	for i, f := range fields {
+		redacted, ok := r.field(f)
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = make([]Field, len(fields))
+			copy(out, fields)
+		}
+		out[i] = redacted
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+func (r *redactor) field(f Field) (Field, bool) {
+	if f.skip() {
+		return f, false
+	}
+	if r.sensitiveKey(f.Key) {
+		return String(f.Key, r.mask), true
+	}
+
+	value := f.Value()
+	switch f.Type {
+	case FieldTypeString, FieldTypeAny:
+	case FieldTypeError:
+		value = f.Interface
+	default:
+		// Numbers, bools, durations and times only go through custom rules.
+		if len(r.redactors) == 0 {
+			return f, false
+		}
+	}
+
+	v, ok := r.value(f.Key, value)
+	if !ok {
+		return f, false
+	}
+	if err, isErr := v.(error); isErr {
+		return NamedErr(f.Key, err), true
+	}
+	if s, isString := v.(string); isString {
+		if f.Type == FieldTypeError {
+			return NamedErr(f.Key, errors.New(s)), true
+		}
+		return String(f.Key, s), true
+	}
+	return Any(f.Key, v), true
+}
+
+// args masks string and error printf arguments matching Patterns.
+func (r *redactor) args(args []any) []any {
+	if len(r.patterns) == 0 {
+		return args
+	}
+
+	var out []any
+	for i, arg := range args {
+		var (
+			masked any
+			ok     bool
+		)
+		switch v := arg.(type) {
+		case string:
+			masked, ok = r.maskString(v)
+		case error:
+			// Stay an error so enrichment still adds the error fields.
+			masked, ok = r.maskError(v)
+		}
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = make([]any, len(args))
+			copy(out, args)
+		}
+		out[i] = masked
+	}
+	if out == nil {
+		return args
+	}
+	return out
+}
+
+// context replaces the fields stored in ctx with their redacted copy.
+func (r *redactor) context(ctx context.Context) context.Context {
+	if ctx == nil {
+		return ctx
+	}
+	fields, ok := ctx.Value(fieldsKey).(map[string]any)
+	if !ok || len(fields) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, fieldsKey, r.mapValues(fields))
+}
@@ -0,0 +1,207 @@
+package logx
+
+import (
+	"context"
+	"hash/fnv"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// SampledOutField carries the number of messages of the same key dropped before this entry.
+	SampledOutField = "sampled_out"
+
+	samplingBuckets = 4096
+)
+
+type SamplingConfig struct {
+	Initial    int           // Messages logged per key in each Tick before sampling starts
+	Thereafter int           // After Initial, log every Thereafter-th message (0 = drop the rest)
+	Tick       time.Duration // Sampling interval (default: 1s)
+}
+
+func (c SamplingConfig) withDefaults() SamplingConfig {
+	if c.Tick <= 0 {
+		c.Tick = time.Second
+	}
+	if c.Initial < 0 {
+		c.Initial = 0
+	}
+	if c.Thereafter < 0 {
+		c.Thereafter = 0
+	}
+	return c
+}
+
+// SampledLogger wraps a Logx and limits how often identical messages are
+// written. Messages are keyed by level and the unformatted message, so
+// "user %s not found" is one key regardless of its arguments. Fatal is
+// never sampled.
+type SampledLogger struct {
+	logger  Logx
+	sampler *sampler
+}
+
+// WithSampling wraps logger with a sampler. Loggers derived through With share the counters.
+func WithSampling(logger Logx, config SamplingConfig) *SampledLogger {
+	return &SampledLogger{
+		logger:  logger,
+		sampler: &sampler{config: config.withDefaults()},
+	}
+}
+
+// Dropped returns the total number of messages dropped since creation.
+func (l *SampledLogger) Dropped() uint64 {
+	return l.sampler.dropped.Load()
+}
+
+func (l *SampledLogger) Debug(ctx context.Context, msg string, args ...any) {
+	if lg, ok := l.sample(ctx, LevelDebug, msg); ok {
+		lg.Debug(ctx, msg, args...)
+	}
+}
+
+func (l *SampledLogger) Info(ctx context.Context, msg string, args ...any) {
+	if lg, ok := l.sample(ctx, LevelInfo, msg); ok {
+		lg.Info(ctx, msg, args...)
+	}
+}
+
+func (l *SampledLogger) Warn(ctx context.Context, msg string, args ...any) {
+	if lg, ok := l.sample(ctx, LevelWarn, msg); ok {
+		lg.Warn(ctx, msg, args...)
+	}
+}
+
+func (l *SampledLogger) Error(ctx context.Context, msg string, args ...any) {
+	if lg, ok := l.sample(ctx, LevelError, msg); ok {
+		lg.Error(ctx, msg, args...)
+	}
+}
+
+func (l *SampledLogger) Fatal(ctx context.Context, msg string, args ...any) {
+	l.fatal(ctx, msg, args...)
+	os.Exit(1)
+}
+
+func (l *SampledLogger) Debugw(ctx context.Context, msg string, fields ...Field) {
+	if lg, ok := l.sample(ctx, LevelDebug, msg); ok {
+		lg.Debugw(ctx, msg, fields...)
+	}
+}
+
+func (l *SampledLogger) Infow(ctx context.Context, msg string, fields ...Field) {
+	if lg, ok := l.sample(ctx, LevelInfo, msg); ok {
+		lg.Infow(ctx, msg, fields...)
+	}
+}
+
+func (l *SampledLogger) Warnw(ctx context.Context, msg string, fields ...Field) {
+	if lg, ok := l.sample(ctx, LevelWarn, msg); ok {
+		lg.Warnw(ctx, msg, fields...)
+	}
+}
+
+func (l *SampledLogger) Errorw(ctx context.Context, msg string, fields ...Field) {
+	if lg, ok := l.sample(ctx, LevelError, msg); ok {
+		lg.Errorw(ctx, msg, fields...)
+	}
+}
+
+func (l *SampledLogger) Fatalw(ctx context.Context, msg string, fields ...Field) {
+	l.fatalw(ctx, msg, fields...)
+	os.Exit(1)
+}
+
+func (l *SampledLogger) fatal(ctx context.Context, msg string, args ...any) {
+	if fw, ok := l.logger.(fatalWriter); ok {
+		fw.fatal(ctx, msg, args...)
+		return
+	}
+	l.logger.Fatal(ctx, msg, args...)
+}
+
+func (l *SampledLogger) fatalw(ctx context.Context, msg string, fields ...Field) {
+	if fw, ok := l.logger.(fatalWriter); ok {
+		fw.fatalw(ctx, msg, fields...)
+		return
+	}
+	l.logger.Fatalw(ctx, msg, fields...)
+}
+
+func (l *SampledLogger) With(ctx context.Context, fields map[string]any) Logx {
+	return &SampledLogger{
+		logger:  l.logger.With(ctx, fields),
+		sampler: l.sampler,
+	}
+}
+
+func (l *SampledLogger) SetLevel(level LogLevel) {
+	l.logger.SetLevel(level)
+}
+
+func (l *SampledLogger) Level() LogLevel {
+	return l.logger.Level()
+}
+
+// sample reports whether the message should be written and returns the
+// logger to write it with, annotated with the number of messages of the same
+// key dropped since the previous written entry.
+func (l *SampledLogger) sample(ctx context.Context, level LogLevel, msg string) (Logx, bool) {
+	if level < l.logger.Level() {
+		return nil, false
+	}
+
+	ok, dropped := l.sampler.check(level, msg)
+	if !ok {
+		return nil, false
+	}
+	if dropped > 0 {
+		return l.logger.With(ctx, map[string]any{SampledOutField: dropped}), true
+	}
+	return l.logger, true
+}
+
+type sampler struct {
+	config  SamplingConfig
+	mu      sync.Mutex
+	buckets [samplingBuckets]sampleCounter
+	dropped atomic.Uint64
+}
+
+type sampleCounter struct {
+	resetAt time.Time
+	count   int
+	dropped uint64 // dropped since the last written entry
+}
+
+func (s *sampler) check(level LogLevel, msg string) (bool, uint64) {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte{byte(level)})
+	_, _ = h.Write([]byte(msg))
+	counter := &s.buckets[h.Sum32()%samplingBuckets]
+
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !now.Before(counter.resetAt) {
+		counter.count = 0
+		counter.resetAt = now.Add(s.config.Tick)
+	}
+
+	counter.count++
+	n := counter.count
+	if n <= s.config.Initial || (s.config.Thereafter > 0 && (n-s.config.Initial)%s.config.Thereafter == 0) {
+		dropped := counter.dropped
+		counter.dropped = 0
+		return true, dropped
+	}
+
+	counter.dropped++
+	s.dropped.Add(1)
+	return false, 0
+}
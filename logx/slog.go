@@ -0,0 +1,326 @@
+package logx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// SlogLevelFatal is the slog level used for Fatal, slog has no fatal level of its own.
+const SlogLevelFatal = slog.LevelError + 4
+
+// SlogLogger implements Logx on top of any slog.Handler.
+type SlogLogger struct {
+	handler slog.Handler
+	fields  map[string]any
+	level   *levelVar
+	enrich  EnrichConfig
+}
+
+func NewSlogLogger(handler slog.Handler) *SlogLogger {
+	return &SlogLogger{
+		handler: handler,
+		fields:  make(map[string]any),
+		level:   newLevelVar(LevelDebug),
+	}
+}
+
+func NewSlogLoggerWithWriter(w io.Writer, format Format) *SlogLogger {
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+	if format == FormatJSON {
+		return NewSlogLogger(slog.NewJSONHandler(w, opts))
+	}
+	return NewSlogLogger(slog.NewTextHandler(w, opts))
+}
+
+func (l *SlogLogger) Debug(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, LevelDebug, msg, args...)
+}
+
+func (l *SlogLogger) Info(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, LevelInfo, msg, args...)
+}
+
+func (l *SlogLogger) Warn(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, LevelWarn, msg, args...)
+}
+
+func (l *SlogLogger) Error(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, LevelError, msg, args...)
+}
+
+func (l *SlogLogger) Fatal(ctx context.Context, msg string, args ...any) {
+	l.fatal(ctx, msg, args...)
+	os.Exit(1)
+}
+
+func (l *SlogLogger) Debugw(ctx context.Context, msg string, fields ...Field) {
+	l.write(ctx, LevelDebug, msg, nil, fields)
+}
+
+func (l *SlogLogger) Infow(ctx context.Context, msg string, fields ...Field) {
+	l.write(ctx, LevelInfo, msg, nil, fields)
+}
+
+func (l *SlogLogger) Warnw(ctx context.Context, msg string, fields ...Field) {
+	l.write(ctx, LevelWarn, msg, nil, fields)
+}
+
+func (l *SlogLogger) Errorw(ctx context.Context, msg string, fields ...Field) {
+	l.write(ctx, LevelError, msg, nil, fields)
+}
+
+func (l *SlogLogger) Fatalw(ctx context.Context, msg string, fields ...Field) {
+	l.fatalw(ctx, msg, fields...)
+	os.Exit(1)
+}
+
+func (l *SlogLogger) fatal(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, LevelFatal, msg, args...)
+}
+
+func (l *SlogLogger) fatalw(ctx context.Context, msg string, fields ...Field) {
+	l.write(ctx, LevelFatal, msg, nil, fields)
+}
+
+func (l *SlogLogger) With(_ context.Context, fields map[string]any) Logx {
+	newFields := make(map[string]any)
+	for k, v := range l.fields {
+		newFields[k] = v
+	}
+	for k, v := range fields {
+		newFields[k] = v
+	}
+
+	return &SlogLogger{
+		handler: l.handler,
+		fields:  newFields,
+		level:   l.level,
+		enrich:  l.enrich,
+	}
+}
+
+func (l *SlogLogger) SetEnrich(config EnrichConfig) {
+	l.enrich = config
+}
+
+func (l *SlogLogger) SetLevel(level LogLevel) {
+	l.level.Set(level)
+}
+
+func (l *SlogLogger) Level() LogLevel {
+	return l.level.Level()
+}
+
+func (l *SlogLogger) log(ctx context.Context, level LogLevel, msg string, args ...any) {
+	if !l.level.Enabled(level) {
+		return
+	}
+
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+
+	l.write(ctx, level, msg, args, nil)
+}
+
+func (l *SlogLogger) write(ctx context.Context, level LogLevel, msg string, args []any, fields []Field) {
+	if !l.level.Enabled(level) {
+		return
+	}
+
+	slogLevel := toSlogLevel(level)
+	if !l.handler.Enabled(ctx, slogLevel) {
+		return
+	}
+
+	fields = l.enrich.apply(level, args, fields)
+
+	record := slog.NewRecord(time.Now(), slogLevel, msg, 0)
+	for k, v := range l.fields {
+		record.AddAttrs(slog.Any(k, v))
+	}
+	for k, v := range FieldsFromContext(ctx) {
+		record.AddAttrs(slog.Any(k, v))
+	}
+	for _, f := range fields {
+		if f.skip() {
+			continue
+		}
+		record.AddAttrs(toSlogAttr(f))
+	}
+
+	_ = l.handler.Handle(ctx, record)
+}
+
+func toSlogAttr(f Field) slog.Attr {
+	switch f.Type {
+	case FieldTypeString:
+		return slog.String(f.Key, f.Str)
+	case FieldTypeInt64:
+		return slog.Int64(f.Key, f.Integer)
+	case FieldTypeFloat64:
+		return slog.Float64(f.Key, f.Float)
+	case FieldTypeBool:
+		return slog.Bool(f.Key, f.Integer == 1)
+	case FieldTypeDuration:
+		return slog.Duration(f.Key, time.Duration(f.Integer))
+	case FieldTypeTime:
+		t, _ := f.Interface.(time.Time)
+		return slog.Time(f.Key, t)
+	default:
+		return slog.Any(f.Key, f.Interface)
+	}
+}
+
+// slogHandler is a slog.Handler that writes records through a Logx, so
+// libraries that only accept *slog.Logger end up in the configured backend
+// together with the context fields.
+type slogHandler struct {
+	logger Logx
+	groups []string
+}
+
+// NewSlogHandler returns a slog.Handler backed by logger. Use it as
+// slog.New(logx.NewSlogHandler(logger)).
+func NewSlogHandler(logger Logx) slog.Handler {
+	return &slogHandler{logger: logger}
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return fromSlogLevel(level) >= h.logger.Level()
+}
+
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	fields := make([]Field, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendSlogAttr(fields, h.groups, attr)
+		return true
+	})
+
+	// Fatal is never forwarded: a slog call must not terminate the process.
+	switch fromSlogLevel(record.Level) {
+	case LevelDebug:
+		h.logger.Debugw(ctx, record.Message, fields...)
+	case LevelInfo:
+		h.logger.Infow(ctx, record.Message, fields...)
+	case LevelWarn:
+		h.logger.Warnw(ctx, record.Message, fields...)
+	default:
+		h.logger.Errorw(ctx, record.Message, fields...)
+	}
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	var fields []Field
+	for _, attr := range attrs {
+		fields = appendSlogAttr(fields, h.groups, attr)
+	}
+
+	return &slogHandler{
+		logger: h.logger.With(context.Background(), fieldsToMap(fields)),
+		groups: h.groups,
+	}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	groups := make([]string, 0, len(h.groups)+1)
+	groups = append(groups, h.groups...)
+	groups = append(groups, name)
+
+	return &slogHandler{
+		logger: h.logger,
+		groups: groups,
+	}
+}
+
+// appendSlogAttr flattens attr into typed fields, joining group names with dots.
+func appendSlogAttr(fields []Field, groups []string, attr slog.Attr) []Field {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		nested := groups
+		if attr.Key != "" {
+			nested = append(append([]string{}, groups...), attr.Key)
+		}
+		for _, a := range attr.Value.Group() {
+			fields = appendSlogAttr(fields, nested, a)
+		}
+		return fields
+	}
+
+	key := attr.Key
+	for i := len(groups) - 1; i >= 0; i-- {
+		key = groups[i] + "." + key
+	}
+
+	v := attr.Value
+	switch v.Kind() {
+	case slog.KindString:
+		return append(fields, String(key, v.String()))
+	case slog.KindInt64:
+		return append(fields, Int64(key, v.Int64()))
+	case slog.KindUint64:
+		return append(fields, Any(key, v.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, Float64(key, v.Float64()))
+	case slog.KindBool:
+		return append(fields, Bool(key, v.Bool()))
+	case slog.KindDuration:
+		return append(fields, Duration(key, v.Duration()))
+	case slog.KindTime:
+		return append(fields, Time(key, v.Time()))
+	default:
+		if err, ok := v.Any().(error); ok {
+			return append(fields, NamedErr(key, err))
+		}
+		return append(fields, Any(key, v.Any()))
+	}
+}
+
+func toSlogLevel(level LogLevel) slog.Level {
+	switch level {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelInfo:
+		return slog.LevelInfo
+	case LevelWarn:
+		return slog.LevelWarn
+	case LevelError:
+		return slog.LevelError
+	case LevelFatal:
+		return SlogLevelFatal
+	default:
+		return slog.LevelDebug
+	}
+}
+
+func fromSlogLevel(level slog.Level) LogLevel {
+	switch {
+	case level < slog.LevelInfo:
+		return LevelDebug
+	case level < slog.LevelWarn:
+		return LevelInfo
+	case level < slog.LevelError:
+		return LevelWarn
+	case level < SlogLevelFatal:
+		return LevelError
+	default:
+		return LevelFatal
+	}
+}
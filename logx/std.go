@@ -3,41 +3,24 @@ package logx
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
-	"maps"
 	"os"
-	"path/filepath"
-
-	"gopkg.in/natefinch/lumberjack.v2"
-)
-
-type LogLevel int
-
-const (
-	LevelDebug LogLevel = iota
-	LevelInfo
-	LevelWarn
-	LevelError
-	LevelFatal
 )
 
 type StdLogger struct {
 	logger *log.Logger
 	fields map[string]any
+	level  *levelVar
+	enrich EnrichConfig
 }
 
 func NewStdLogger() *StdLogger {
-	return &StdLogger{
-		logger: log.New(os.Stdout, "", log.LstdFlags|log.Lshortfile),
-		fields: make(map[string]any),
-	}
+	return NewStdLoggerWithWriter(os.Stdout, "")
 }
 
 func NewStdLoggerWithPrefix(prefix string) *StdLogger {
-	return &StdLogger{
-		logger: log.New(os.Stdout, prefix, log.LstdFlags|log.Lshortfile),
-		fields: make(map[string]any),
-	}
+	return NewStdLoggerWithWriter(os.Stdout, prefix)
 }
 
 func (l *StdLogger) Debug(ctx context.Context, msg string, args ...any) {
@@ -57,10 +40,39 @@ func (l *StdLogger) Error(ctx context.Context, msg string, args ...any) {
 }
 
 func (l *StdLogger) Fatal(ctx context.Context, msg string, args ...any) {
-	l.log(ctx, LevelFatal, msg, args...)
+	l.fatal(ctx, msg, args...)
+	os.Exit(1)
+}
+
+func (l *StdLogger) Debugw(ctx context.Context, msg string, fields ...Field) {
+	l.write(ctx, LevelDebug, msg, nil, fields)
+}
+
+func (l *StdLogger) Infow(ctx context.Context, msg string, fields ...Field) {
+	l.write(ctx, LevelInfo, msg, nil, fields)
+}
+
+func (l *StdLogger) Warnw(ctx context.Context, msg string, fields ...Field) {
+	l.write(ctx, LevelWarn, msg, nil, fields)
+}
+
+func (l *StdLogger) Errorw(ctx context.Context, msg string, fields ...Field) {
+	l.write(ctx, LevelError, msg, nil, fields)
+}
+
+func (l *StdLogger) Fatalw(ctx context.Context, msg string, fields ...Field) {
+	l.fatalw(ctx, msg, fields...)
 	os.Exit(1)
 }
 
+func (l *StdLogger) fatal(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, LevelFatal, msg, args...)
+}
+
+func (l *StdLogger) fatalw(ctx context.Context, msg string, fields ...Field) {
+	l.write(ctx, LevelFatal, msg, nil, fields)
+}
+
 func (l *StdLogger) With(_ context.Context, fields map[string]any) Logx {
 	newFields := make(map[string]any)
 	for k, v := range l.fields {
@@ -73,41 +85,61 @@ func (l *StdLogger) With(_ context.Context, fields map[string]any) Logx {
 	return &StdLogger{
 		logger: l.logger,
 		fields: newFields,
+		level:  l.level,
+		enrich: l.enrich,
 	}
 }
 
-func StdLoggerWithRotation(filePath string, rotConfig *RotationConfig) (*StdLogger, error) {
-	dir := filepath.Dir(filePath)
-	if err := os.MkdirAll(dir, 0700); err != nil {
-		return nil, fmt.Errorf("[pkg.logx.StdLoggerWithRotation] failed to create log directory: %w", err)
-	}
+func (l *StdLogger) SetEnrich(config EnrichConfig) {
+	l.enrich = config
+}
 
-	if rotConfig == nil {
-		rotConfig = DefaultRotationConfig()
-	}
+func (l *StdLogger) SetLevel(level LogLevel) {
+	l.level.Set(level)
+}
+
+func (l *StdLogger) Level() LogLevel {
+	return l.level.Level()
+}
 
-	lumberjackLogger := &lumberjack.Logger{
-		Filename:   filePath,
-		MaxSize:    rotConfig.MaxSize,
-		MaxBackups: rotConfig.MaxBackups,
-		MaxAge:     rotConfig.MaxAge,
-		Compress:   rotConfig.Compress,
-		LocalTime:  rotConfig.LocalTime,
+func StdLoggerWithRotation(filePath string, rotConfig *RotationConfig) (*StdLogger, error) {
+	lumberjackLogger, err := newRotationWriter(filePath, rotConfig)
+	if err != nil {
+		return nil, fmt.Errorf("[pkg.logx.StdLoggerWithRotation] %w", err)
 	}
 
+	return NewStdLoggerWithWriter(lumberjackLogger, ""), nil
+}
+
+func NewStdLoggerWithWriter(w io.Writer, prefix string) *StdLogger {
 	return &StdLogger{
-		logger: log.New(lumberjackLogger, "", log.LstdFlags|log.Lshortfile),
+		logger: log.New(w, prefix, log.LstdFlags),
 		fields: make(map[string]any),
-	}, nil
+		level:  newLevelVar(LevelDebug),
+		enrich: EnrichConfig{Caller: true},
+	}
 }
 
 func (l *StdLogger) log(ctx context.Context, level LogLevel, msg string, args ...any) {
-	formattedMsg := msg
+	if !l.level.Enabled(level) {
+		return
+	}
+
 	if len(args) > 0 {
-		formattedMsg = fmt.Sprintf(msg, args...)
+		msg = fmt.Sprintf(msg, args...)
 	}
 
-	contextFields := extractContextFields(ctx)
+	l.write(ctx, level, msg, args, nil)
+}
+
+func (l *StdLogger) write(ctx context.Context, level LogLevel, msg string, args []any, fields []Field) {
+	if !l.level.Enabled(level) {
+		return
+	}
+
+	fields = l.enrich.apply(level, args, fields)
+
+	contextFields := FieldsFromContext(ctx)
 
 	allFields := make(map[string]any)
 	for k, v := range l.fields {
@@ -116,8 +148,11 @@ func (l *StdLogger) log(ctx context.Context, level LogLevel, msg string, args ..
 	for k, v := range contextFields {
 		allFields[k] = v
 	}
+	for k, v := range fieldsToMap(fields) {
+		allFields[k] = v
+	}
 
-	finalMsg := fmt.Sprintf("[%s] %s", level.String(), formattedMsg)
+	finalMsg := fmt.Sprintf("[%s] %s", level.String(), msg)
 	if len(allFields) > 0 {
 		finalMsg += " " + formatFields(allFields)
 	}
@@ -125,43 +160,6 @@ func (l *StdLogger) log(ctx context.Context, level LogLevel, msg string, args ..
 	l.logger.Println(finalMsg)
 }
 
-func (l LogLevel) String() string {
-	switch l {
-	case LevelDebug:
-		return "DEBUG"
-	case LevelInfo:
-		return "INFO"
-	case LevelWarn:
-		return "WARN"
-	case LevelError:
-		return "ERROR"
-	case LevelFatal:
-		return "FATAL"
-	default:
-		return "UNKNOWN"
-	}
-}
-
-func extractContextFields(ctx context.Context) map[string]any {
-	fields := make(map[string]any)
-
-	if v := ctx.Value("fields"); v != nil {
-		if f, ok := v.(map[string]any); ok {
-			maps.Copy(fields, f)
-		}
-	}
-
-	if reqID := ctx.Value("request_id"); reqID != nil {
-		fields["request_id"] = reqID
-	}
-
-	if traceID := ctx.Value("trace_id"); traceID != nil {
-		fields["trace_id"] = traceID
-	}
-
-	return fields
-}
-
 func formatFields(fields map[string]any) string {
 	if len(fields) == 0 {
 		return ""
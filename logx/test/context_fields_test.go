@@ -1,7 +1,9 @@
 package logx_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"testing"
 
 	logx "github.com/vixyninja/go-blocks/logx"
@@ -10,16 +12,59 @@ import (
 func TestContextFields(t *testing.T) {
 	ctx := context.Background()
 
-	type Key string
-	type Value any
-
-	ctx = context.WithValue(ctx, Key("request_id"), Value("req-123"))
-	ctx = context.WithValue(ctx, Key("user_id"), Value("user-456"))
-	ctx = context.WithValue(ctx, Key("fields"), Value(map[string]any{"service": "test-service"}))
+	ctx = logx.ContextWithRequestID(ctx, "req-123")
+	ctx = logx.ContextWithTraceID(ctx, "trace-789")
+	ctx = logx.ContextWithFields(ctx, map[string]any{"service": "test-service"})
+	ctx = logx.ContextWithFields(ctx, map[string]any{"user_id": "user-456"})
 
-	logger := logx.NewZerologJSONLogger()
-	logger.Info(ctx, "message with context fields")
+	var buf bytes.Buffer
+	logger := logx.NewZerologLoggerWithWriter(&buf, logx.FormatJSON)
 
 	child := logger.With(ctx, map[string]any{"component": "auth"})
 	child.Info(ctx, "child with context")
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+
+	want := map[string]any{
+		"request_id": "req-123",
+		"trace_id":   "trace-789",
+		"service":    "test-service",
+		"user_id":    "user-456",
+		"component":  "auth",
+	}
+	for k, v := range want {
+		if entry[k] != v {
+			t.Errorf("expected %s=%v, got %v", k, v, entry[k])
+		}
+	}
+}
+
+func TestFieldsFromContext(t *testing.T) {
+	ctx := context.Background()
+	if fields := logx.FieldsFromContext(ctx); len(fields) != 0 {
+		t.Fatalf("expected no fields, got %v", fields)
+	}
+
+	type Key string
+	ctx = context.WithValue(ctx, Key("request_id"), "ignored")
+	if fields := logx.FieldsFromContext(ctx); len(fields) != 0 {
+		t.Fatalf("foreign keys must not be picked up, got %v", fields)
+	}
+
+	ctx = logx.ContextWithRequestID(ctx, "req-1")
+	fields := logx.FieldsFromContext(ctx)
+	if fields[logx.RequestIDField] != "req-1" {
+		t.Fatalf("expected request_id, got %v", fields)
+	}
+	if logx.RequestIDFromContext(ctx) != "req-1" {
+		t.Fatalf("expected RequestIDFromContext to return req-1")
+	}
+
+	fields["mutated"] = true
+	if _, ok := logx.FieldsFromContext(ctx)["mutated"]; ok {
+		t.Fatal("FieldsFromContext must return a copy")
+	}
 }
@@ -0,0 +1,134 @@
+package logx_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	logx "github.com/vixyninja/go-blocks/logx"
+)
+
+const callerFile = "test/enrich_test.go:"
+
+func TestEnrich_CallerPointsAtCallSite(t *testing.T) {
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	std := logx.NewStdLoggerWithWriter(&buf, "")
+
+	std.Info(ctx, "direct")
+	std.With(ctx, map[string]any{"k": "v"}).Infow(ctx, "child")
+	logx.WithSampling(std, logx.SamplingConfig{Initial: 10}).Warn(ctx, "sampled")
+	slog.New(logx.NewSlogHandler(std)).Info("via slog")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %d:\n%s", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, "caller="+callerFile) {
+			t.Errorf("expected caller in this file, got %q", line)
+		}
+		if strings.Contains(line, "std.go") {
+			t.Errorf("caller must not point inside logx: %q", line)
+		}
+	}
+}
+
+func TestEnrich_CallerSkip(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logx.NewZerologLoggerWithWriter(&buf, logx.FormatJSON)
+	logger.SetEnrich(logx.EnrichConfig{Caller: true, CallerSkip: 1})
+
+	helperLine := logThroughHelper(logger)
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	caller, _ := entry[logx.CallerField].(string)
+	if !strings.HasPrefix(caller, callerFile) {
+		t.Fatalf("expected caller in this file, got %q", caller)
+	}
+	if caller == callerFile+strconv.Itoa(helperLine) {
+		t.Fatalf("expected caller of the helper, got the helper itself: %q", caller)
+	}
+}
+
+func logThroughHelper(l logx.Logx) int {
+	_, _, line, _ := runtime.Caller(0)
+	l.Info(context.Background(), "from helper")
+	return line + 1
+}
+
+func TestEnrich_ErrorArgAndChain(t *testing.T) {
+	ctx := context.Background()
+	root := errors.New("connection refused")
+	err := fmt.Errorf("load user: %w", fmt.Errorf("query: %w", root))
+
+	cases := []struct {
+		name   string
+		create func(*bytes.Buffer) logx.Logx
+	}{
+		{"logrus", func(b *bytes.Buffer) logx.Logx { return logx.NewLogrusLoggerWithWriter(b, logx.FormatJSON) }},
+		{"zap", func(b *bytes.Buffer) logx.Logx { return logx.NewZapLoggerWithWriter(b, logx.FormatJSON) }},
+		{"zerolog", func(b *bytes.Buffer) logx.Logx { return logx.NewZerologLoggerWithWriter(b, logx.FormatJSON) }},
+		{"slog", func(b *bytes.Buffer) logx.Logx { return logx.NewSlogLoggerWithWriter(b, logx.FormatJSON) }},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			cs.create(&buf).Error(ctx, "request failed: %v", err)
+
+			var entry map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+			}
+			if entry["error"] != err.Error() {
+				t.Errorf("expected error field %q, got %v", err.Error(), entry["error"])
+			}
+			chain, _ := entry["error.chain"].([]any)
+			if len(chain) != 3 || chain[2] != root.Error() {
+				t.Errorf("expected 3-element error chain ending in root cause, got %v", entry["error.chain"])
+			}
+		})
+	}
+}
+
+func TestEnrich_StackTraceOnErrorOnly(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := logx.NewZerologLoggerWithWriter(&buf, logx.FormatJSON)
+	logger.SetEnrich(logx.EnrichConfig{StackTrace: true})
+
+	logger.Info(ctx, "no stack")
+	logger.Errorw(ctx, "with stack", logx.Err(errors.New("boom")))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	if strings.Contains(lines[0], logx.StackTraceField) {
+		t.Errorf("info entry must not carry a stack trace: %s", lines[0])
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	stack, _ := entry[logx.StackTraceField].(string)
+	if !strings.Contains(stack, "TestEnrich_StackTraceOnErrorOnly") {
+		t.Errorf("expected stack to start at the test, got %q", stack)
+	}
+	if strings.Contains(stack, "logx.(*ZerologLogger)") {
+		t.Errorf("stack must not include logx frames: %q", stack)
+	}
+}
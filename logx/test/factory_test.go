@@ -2,6 +2,9 @@ package logx_test
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	logx "github.com/vixyninja/go-blocks/logx"
@@ -20,3 +23,46 @@ func TestFactory_Basic(t *testing.T) {
 		lg.With(ctx, map[string]any{"k": "v"}).Info(ctx, "child")
 	}
 }
+
+func TestFactory_ZapEnrichDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "zap.log")
+	lg := logx.NewLogger(logx.LoggerConfig{Type: logx.LoggerTypeZap, Format: logx.FormatJSON, Output: path})
+	lg.Error(context.Background(), "boom")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	for _, key := range []string{logx.CallerField, logx.StackTraceField} {
+		if !strings.Contains(string(data), `"`+key+`"`) {
+			t.Errorf("expected %q in zap output, got %s", key, data)
+		}
+	}
+}
+
+func TestFactory_NewLoggerSinkFallback(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	good := filepath.Join(t.TempDir(), "good.log")
+
+	config := logx.LoggerConfig{Sinks: []logx.LoggerConfig{
+		{Type: logx.LoggerTypeStd, Output: filepath.Join(file, "bad.log")},
+		{Type: logx.LoggerTypeStd, Output: good},
+	}}
+	if _, err := logx.BuildLogger(config); err == nil {
+		t.Fatal("expected BuildLogger to report the broken sink")
+	}
+
+	lg := logx.NewLogger(config)
+	if lg == nil {
+		t.Fatal("expected NewLogger to fall back instead of returning nil")
+	}
+	lg.Info(context.Background(), "still logging")
+
+	data, _ := os.ReadFile(good)
+	if !strings.Contains(string(data), "still logging") {
+		t.Errorf("expected the healthy sink to keep logging, got %q", data)
+	}
+}
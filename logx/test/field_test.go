@@ -0,0 +1,94 @@
+package logx_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	logx "github.com/vixyninja/go-blocks/logx"
+)
+
+func TestStructuredLogging_JSONBackends(t *testing.T) {
+	ctx := logx.ContextWithRequestID(context.Background(), "req-1")
+
+	cases := []struct {
+		name   string
+		msgKey string
+		create func(*bytes.Buffer) logx.Logx
+	}{
+		{"logrus", "msg", func(b *bytes.Buffer) logx.Logx { return logx.NewLogrusLoggerWithWriter(b, logx.FormatJSON) }},
+		{"zap", "msg", func(b *bytes.Buffer) logx.Logx { return logx.NewZapLoggerWithWriter(b, logx.FormatJSON) }},
+		{"zerolog", "message", func(b *bytes.Buffer) logx.Logx { return logx.NewZerologLoggerWithWriter(b, logx.FormatJSON) }},
+		{"slog", "msg", func(b *bytes.Buffer) logx.Logx { return logx.NewSlogLoggerWithWriter(b, logx.FormatJSON) }},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := cs.create(&buf)
+
+			logger.Infow(ctx, "100% structured",
+				logx.String("user", "bob"),
+				logx.Int("attempt", 3),
+				logx.Bool("cached", true),
+				logx.Duration("latency", time.Second),
+				logx.Err(errors.New("boom")),
+				logx.Err(nil),
+			)
+
+			var entry map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+			}
+
+			if entry[cs.msgKey] != "100% structured" {
+				t.Errorf("message must not be formatted, got %v", entry[cs.msgKey])
+			}
+			if entry["user"] != "bob" {
+				t.Errorf("expected user=bob, got %v", entry["user"])
+			}
+			if entry["attempt"] != float64(3) {
+				t.Errorf("expected attempt=3, got %v", entry["attempt"])
+			}
+			if entry["cached"] != true {
+				t.Errorf("expected cached=true, got %v", entry["cached"])
+			}
+			if _, ok := entry["latency"]; !ok {
+				t.Errorf("expected latency field, got %v", entry)
+			}
+			if entry["error"] != "boom" {
+				t.Errorf("expected error=boom, got %v", entry["error"])
+			}
+			if entry["request_id"] != "req-1" {
+				t.Errorf("expected request_id from context, got %v", entry["request_id"])
+			}
+		})
+	}
+}
+
+func TestStructuredLogging_Std(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logx.NewStdLoggerWithWriter(&buf, "")
+
+	logger.Warnw(context.Background(), "structured", logx.String("user", "bob"), logx.Int64("id", 7))
+
+	out := buf.String()
+	if !strings.Contains(out, "[WARN] structured") || !strings.Contains(out, "user=bob") || !strings.Contains(out, "id=7") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestStructuredLogging_RespectsLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logx.NewZapLoggerWithWriter(&buf, logx.FormatJSON)
+	logger.SetLevel(logx.LevelError)
+
+	logger.Infow(context.Background(), "dropped", logx.String("k", "v"))
+	if buf.Len() != 0 {
+		t.Fatalf("expected nothing logged, got %q", buf.String())
+	}
+}
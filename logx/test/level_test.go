@@ -0,0 +1,107 @@
+package logx_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	logx "github.com/vixyninja/go-blocks/logx"
+)
+
+func TestLevel_RuntimeChange(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		name   string
+		create func(string) (logx.Logx, error)
+	}{
+		{"std", func(p string) (logx.Logx, error) { return logx.StdLoggerWithRotation(p, nil) }},
+		{"logrus", func(p string) (logx.Logx, error) { return logx.LogrusLoggerWithRotation(p, nil, logx.FormatJSON) }},
+		{"zap", func(p string) (logx.Logx, error) { return logx.ZapLoggerWithRotation(p, nil, logx.FormatJSON) }},
+		{"zerolog", func(p string) (logx.Logx, error) { return logx.ZerologLoggerWithRotation(p, nil, logx.FormatJSON) }},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), cs.name+".log")
+			logger, err := cs.create(path)
+			if err != nil {
+				t.Fatalf("create logger: %v", err)
+			}
+
+			logger.SetLevel(logx.LevelWarn)
+			if logger.Level() != logx.LevelWarn {
+				t.Fatalf("expected level %s, got %s", logx.LevelWarn, logger.Level())
+			}
+
+			child := logger.With(ctx, map[string]any{"k": "v"})
+			child.Info(ctx, "hidden-info")
+			child.Warn(ctx, "shown-warn")
+
+			logger.SetLevel(logx.LevelDebug)
+			child.Debug(ctx, "shown-debug")
+
+			if zl, ok := logger.(*logx.ZapLogger); ok {
+				_ = zl.Sync()
+			}
+
+			b, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read log file: %v", err)
+			}
+			out := string(b)
+			if strings.Contains(out, "hidden-info") {
+				t.Errorf("info message should be filtered at warn level: %s", out)
+			}
+			if !strings.Contains(out, "shown-warn") {
+				t.Errorf("warn message missing: %s", out)
+			}
+			if !strings.Contains(out, "shown-debug") {
+				t.Errorf("debug message missing after lowering level: %s", out)
+			}
+		})
+	}
+}
+
+func TestLevel_FromConfig(t *testing.T) {
+	types := []logx.LoggerType{logx.LoggerTypeStd, logx.LoggerTypeLogrus, logx.LoggerTypeZap, logx.LoggerTypeZerolog}
+	for _, tp := range types {
+		lg := logx.NewLogger(logx.LoggerConfig{Type: tp, Format: logx.FormatJSON, Level: logx.LevelError})
+		if lg.Level() != logx.LevelError {
+			t.Errorf("%s: expected level %s, got %s", tp, logx.LevelError, lg.Level())
+		}
+	}
+}
+
+func TestLevel_ZerologKeepsGlobalLevel(t *testing.T) {
+	before := zerolog.GlobalLevel()
+	level := zerolog.ErrorLevel
+	_ = logx.NewZerologLoggerWithLevel(level)
+	_ = logx.NewZerologLogger()
+	if zerolog.GlobalLevel() != before {
+		t.Fatalf("global zerolog level changed from %s to %s", before, zerolog.GlobalLevel())
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := map[string]logx.LogLevel{
+		"debug":   logx.LevelDebug,
+		"INFO":    logx.LevelInfo,
+		"warning": logx.LevelWarn,
+		"error":   logx.LevelError,
+		"fatal":   logx.LevelFatal,
+	}
+	for in, want := range tests {
+		got, err := logx.ParseLevel(in)
+		if err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+
+	if _, err := logx.ParseLevel("verbose"); err == nil {
+		t.Error("expected error for unknown level")
+	}
+}
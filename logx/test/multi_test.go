@@ -0,0 +1,78 @@
+package logx_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	logx "github.com/vixyninja/go-blocks/logx"
+	"github.com/vixyninja/go-blocks/logx/logxtest"
+)
+
+func TestMulti_SinksKeepOwnFormatAndLevel(t *testing.T) {
+	ctx := context.Background()
+
+	var console, file bytes.Buffer
+	consoleLogger := logx.NewStdLoggerWithWriter(&console, "")
+	consoleLogger.SetLevel(logx.LevelInfo)
+	fileLogger := logx.NewZerologLoggerWithWriter(&file, logx.FormatJSON)
+	fileLogger.SetLevel(logx.LevelDebug)
+
+	logger := logx.NewMulti(consoleLogger, fileLogger)
+	logger.Debug(ctx, "debug only in file")
+	logger.Infow(ctx, "both", logx.String("user", "42"))
+
+	if strings.Contains(console.String(), "debug only in file") {
+		t.Errorf("console sink must drop debug entries: %s", console.String())
+	}
+	if !strings.Contains(console.String(), "both") {
+		t.Errorf("expected info entry on console, got %q", console.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(file.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 JSON lines in file sink, got %d:\n%s", len(lines), file.String())
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil {
+		t.Fatalf("invalid JSON %q: %v", lines[1], err)
+	}
+	if entry["user"] != "42" {
+		t.Errorf("expected user field, got %v", entry["user"])
+	}
+
+	if logger.Level() != logx.LevelDebug {
+		t.Errorf("expected most verbose level, got %s", logger.Level())
+	}
+}
+
+func TestMulti_WithPropagatesToEverySink(t *testing.T) {
+	ctx := context.Background()
+	first, second := logxtest.NewRecorder(), logxtest.NewRecorder()
+
+	child := logx.NewMulti(first, second).With(ctx, map[string]any{"component": "billing"})
+	child.Warn(ctx, "retrying")
+
+	for i, r := range []*logxtest.Recorder{first, second} {
+		if len(r.FilterField("component", "billing")) != 1 {
+			t.Errorf("sink %d: expected entry with component field, got %v", i, r.Entries())
+		}
+	}
+}
+
+func TestMulti_FromConfig(t *testing.T) {
+	lg, err := logx.BuildLogger(logx.LoggerConfig{
+		Sinks: []logx.LoggerConfig{
+			{Type: logx.LoggerTypeStd, Output: "stdout", Level: logx.LevelInfo},
+			{Type: logx.LoggerTypeZap, Format: logx.FormatJSON, Output: "stderr", Level: logx.LevelDebug},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := lg.(*logx.MultiLogger); !ok {
+		t.Fatalf("expected *logx.MultiLogger, got %T", lg)
+	}
+}
@@ -0,0 +1,71 @@
+package logx_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	logx "github.com/vixyninja/go-blocks/logx"
+)
+
+func TestFactory_FileOutput(t *testing.T) {
+	ctx := context.Background()
+
+	types := []logx.LoggerType{logx.LoggerTypeStd, logx.LoggerTypeLogrus, logx.LoggerTypeZap, logx.LoggerTypeZerolog}
+	formats := []logx.Format{logx.FormatJSON, logx.FormatText}
+
+	for _, tp := range types {
+		for _, format := range formats {
+			for _, rotate := range []bool{false, true} {
+				name := string(tp) + "_" + string(format)
+				var rotation *logx.RotationConfig
+				if rotate {
+					name += "_rotation"
+					rotation = logx.DefaultRotationConfig()
+				}
+
+				t.Run(name, func(t *testing.T) {
+					path := filepath.Join(t.TempDir(), "nested", name+".log")
+					logger, err := logx.BuildLogger(logx.LoggerConfig{
+						Type:     tp,
+						Format:   format,
+						Output:   path,
+						Rotation: rotation,
+					})
+					if err != nil {
+						t.Fatalf("BuildLogger() error = %v", err)
+					}
+
+					logger.With(ctx, map[string]any{"component": "output"}).Info(ctx, "written to %s", name)
+
+					b, err := os.ReadFile(path)
+					if err != nil {
+						t.Fatalf("read log file: %v", err)
+					}
+					if !strings.Contains(string(b), "written to "+name) {
+						t.Fatalf("expected message in file, got %q", string(b))
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestFactory_InvalidOutput(t *testing.T) {
+	dir := t.TempDir()
+	blocker := filepath.Join(dir, "file")
+	if err := os.WriteFile(blocker, []byte("x"), 0600); err != nil {
+		t.Fatalf("write blocker: %v", err)
+	}
+
+	cfg := logx.LoggerConfig{Type: logx.LoggerTypeZap, Output: filepath.Join(blocker, "app.log")}
+	if _, err := logx.BuildLogger(cfg); err == nil {
+		t.Fatal("expected error for output below a regular file")
+	}
+
+	if lg := logx.NewLogger(cfg); lg == nil {
+		t.Fatal("NewLogger should fall back to stdout")
+	}
+}
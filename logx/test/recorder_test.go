@@ -0,0 +1,88 @@
+package logx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	logx "github.com/vixyninja/go-blocks/logx"
+	"github.com/vixyninja/go-blocks/logx/logxtest"
+)
+
+func TestRecorder_RecordsEntries(t *testing.T) {
+	ctx := logx.ContextWithRequestID(context.Background(), "req-42")
+	rec := logxtest.NewRecorder()
+
+	var logger logx.Logx = rec
+	child := logger.With(ctx, map[string]any{"component": "billing"})
+
+	child.Info(ctx, "charged %d cents", 250)
+	child.Errorw(ctx, "charge failed", logx.Err(errors.New("card declined")), logx.String("user", "bob"))
+	logger.Debug(ctx, "debug message")
+
+	entries := rec.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+
+	first := entries[0]
+	if first.Message != "charged 250 cents" || first.Template != "charged %d cents" {
+		t.Errorf("unexpected message/template: %q / %q", first.Message, first.Template)
+	}
+	if first.Fields["component"] != "billing" {
+		t.Errorf("expected With field, got %v", first.Fields)
+	}
+	if first.ContextFields[logx.RequestIDField] != "req-42" {
+		t.Errorf("expected request_id from context, got %v", first.ContextFields)
+	}
+
+	errs := rec.FilterLevel(logx.LevelError)
+	if len(errs) != 1 || errs[0].Fields["error"] != "card declined" || errs[0].Fields["user"] != "bob" {
+		t.Errorf("unexpected error entries: %v", errs)
+	}
+
+	if got := rec.FilterField("component", "billing"); len(got) != 2 {
+		t.Errorf("expected 2 entries with component field, got %d", len(got))
+	}
+
+	rec.AssertContains(t, "charged 250")
+	rec.AssertLogged(t, logx.LevelError, "charge failed")
+	rec.AssertNotContains(t, "password")
+}
+
+func TestRecorder_LevelAndReset(t *testing.T) {
+	ctx := context.Background()
+	rec := logxtest.NewRecorder()
+	rec.SetLevel(logx.LevelWarn)
+
+	rec.Info(ctx, "dropped")
+	rec.Warn(ctx, "kept")
+	if rec.Len() != 1 {
+		t.Fatalf("expected 1 entry, got %d", rec.Len())
+	}
+
+	rec.Reset()
+	if rec.Len() != 0 {
+		t.Fatalf("expected no entries after Reset, got %d", rec.Len())
+	}
+}
+
+func TestRecorder_AssertFailures(t *testing.T) {
+	rec := logxtest.NewRecorder()
+	rec.Info(context.Background(), "hello")
+
+	ft := &fakeTB{TB: t}
+	rec.AssertContains(ft, "missing")
+	if !ft.failed {
+		t.Error("AssertContains should fail for a missing message")
+	}
+}
+
+type fakeTB struct {
+	testing.TB
+	failed bool
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(string, ...any) { f.failed = true }
@@ -0,0 +1,201 @@
+package logx_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	logx "github.com/vixyninja/go-blocks/logx"
+	"github.com/vixyninja/go-blocks/logx/logxtest"
+)
+
+func TestRedact_KeysAtAnyDepth(t *testing.T) {
+	ctx := logx.ContextWithFields(context.Background(), map[string]any{"Authorization": "Bearer abc"})
+	rec := logxtest.NewRecorder()
+	logger, err := logx.WithRedaction(rec, *logx.DefaultRedactConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	child := logger.With(ctx, map[string]any{
+		"user": map[string]any{"name": "alice", "Password": "hunter2"},
+	})
+	child.Infow(ctx, "login", logx.String("refresh_token", "r-123"), logx.Int("attempt", 1))
+
+	entries := rec.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	e := entries[0]
+
+	user, _ := e.Fields["user"].(map[string]any)
+	if user["Password"] != logx.DefaultRedactMask || user["name"] != "alice" {
+		t.Errorf("expected nested password masked, got %v", e.Fields["user"])
+	}
+	if e.Fields["refresh_token"] != logx.DefaultRedactMask {
+		t.Errorf("expected refresh_token masked, got %v", e.Fields["refresh_token"])
+	}
+	if e.Fields["attempt"] != int64(1) {
+		t.Errorf("expected attempt untouched, got %v", e.Fields["attempt"])
+	}
+	if e.ContextFields["Authorization"] != logx.DefaultRedactMask {
+		t.Errorf("expected context authorization masked, got %v", e.ContextFields["Authorization"])
+	}
+}
+
+func TestRedact_PatternsAndCustomRedactor(t *testing.T) {
+	ctx := context.Background()
+	rec := logxtest.NewRecorder()
+	config := logx.DefaultRedactConfig()
+	config.Mask = "***"
+	config.Redactors = []logx.Redactor{logx.RedactorFunc(func(key string, value any) (any, bool) {
+		if key == "email" {
+			return "a***@example.com", true
+		}
+		return nil, false
+	})}
+
+	logger, err := logx.WithRedaction(rec, *config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logger.Info(ctx, "charging card %s", "4111 1111 1111 1111")
+	logger.Infow(ctx, "customer", logx.String("email", "alice@example.com"), logx.String("note", "card 4111111111111111 ok"))
+
+	rec.AssertContains(t, "charging card ***")
+	rec.AssertNotContains(t, "4111")
+
+	fields := rec.Entries()[1].Fields
+	if fields["email"] != "a***@example.com" {
+		t.Errorf("expected custom redaction, got %v", fields["email"])
+	}
+	if fields["note"] != "card *** ok" {
+		t.Errorf("expected card number masked in value, got %v", fields["note"])
+	}
+}
+
+func TestRedact_CardNumbersNeedLuhn(t *testing.T) {
+	ctx := context.Background()
+	rec := logxtest.NewRecorder()
+	logger, err := logx.WithRedaction(rec, *logx.DefaultRedactConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logger.Infow(ctx, "order",
+		logx.String("card", "pan 5555-5555-5555-4444"),
+		logx.String("created_ms", "1700000000000"),
+		logx.String("order_id", "1234567890123456789"))
+	logger.Info(ctx, "event at %s", "1700000000000")
+
+	fields := rec.Entries()[0].Fields
+	if fields["card"] != "pan "+logx.DefaultRedactMask {
+		t.Errorf("expected a valid card number masked, got %v", fields["card"])
+	}
+	if fields["created_ms"] != "1700000000000" || fields["order_id"] != "1234567890123456789" {
+		t.Errorf("expected non-card digit runs kept, got %v", fields)
+	}
+	rec.AssertContains(t, "event at 1700000000000")
+}
+
+func TestRedact_ErrorArgsKeepErrorFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logx.WithRedaction(logx.NewZapLoggerWithWriter(&buf, logx.FormatJSON), *logx.DefaultRedactConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cause := errors.New("card 5555-5555-5555-4444 declined")
+	logger.Error(context.Background(), "payment failed: %v", fmt.Errorf("charge: %w", cause))
+
+	if strings.Contains(buf.String(), "5555-5555-5555-4444") {
+		t.Fatalf("card number leaked: %s", buf.String())
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	if entry[logx.ErrorFieldKey] != "charge: card "+logx.DefaultRedactMask+" declined" {
+		t.Errorf("expected the masked error field, got %v", entry[logx.ErrorFieldKey])
+	}
+	if chain, _ := entry[logx.ErrorFieldKey+logx.ErrorChainSuffix].([]any); len(chain) != 2 {
+		t.Errorf("expected the masked error chain, got %v", entry[logx.ErrorFieldKey+logx.ErrorChainSuffix])
+	}
+}
+
+func TestRedact_EveryBackend(t *testing.T) {
+	ctx := context.Background()
+
+	for _, typ := range []logx.LoggerType{logx.LoggerTypeLogrus, logx.LoggerTypeZap, logx.LoggerTypeZerolog, logx.LoggerTypeSlog} {
+		t.Run(string(typ), func(t *testing.T) {
+			var buf bytes.Buffer
+			backend := newJSONBackend(typ, &buf)
+			logger, err := logx.WithRedaction(backend, *logx.DefaultRedactConfig())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			logger.With(ctx, map[string]any{"password": "hunter2"}).Warnw(ctx, "payload", logx.Any("body", map[string]any{"token": "t-1"}))
+
+			if strings.Contains(buf.String(), "hunter2") || strings.Contains(buf.String(), "t-1") {
+				t.Fatalf("secret leaked: %s", buf.String())
+			}
+			var entry map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+			}
+			if entry["password"] != logx.DefaultRedactMask {
+				t.Errorf("expected password masked, got %v", entry["password"])
+			}
+		})
+	}
+}
+
+func TestRedact_InvalidPattern(t *testing.T) {
+	_, err := logx.BuildLogger(logx.LoggerConfig{
+		Type:   logx.LoggerTypeStd,
+		Redact: &logx.RedactConfig{Patterns: []string{"("}},
+	})
+	if err == nil {
+		t.Fatal("expected error for invalid pattern")
+	}
+}
+
+func TestRedact_InvalidPatternNewLogger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "redact.log")
+	lg := logx.NewLogger(logx.LoggerConfig{
+		Type:   logx.LoggerTypeSlog,
+		Format: logx.FormatJSON,
+		Output: path,
+		Redact: &logx.RedactConfig{Keys: []string{"password"}, Patterns: []string{"("}},
+	})
+	if lg == nil {
+		t.Fatal("expected NewLogger to skip the bad pattern instead of returning nil")
+	}
+	lg.Infow(context.Background(), "login", logx.String("password", "hunter2"))
+
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "hunter2") || !strings.Contains(string(data), logx.DefaultRedactMask) {
+		t.Errorf("expected key redaction to stay on, got %s", data)
+	}
+}
+
+func newJSONBackend(typ logx.LoggerType, buf *bytes.Buffer) logx.Logx {
+	switch typ {
+	case logx.LoggerTypeLogrus:
+		return logx.NewLogrusLoggerWithWriter(buf, logx.FormatJSON)
+	case logx.LoggerTypeZap:
+		return logx.NewZapLoggerWithWriter(buf, logx.FormatJSON)
+	case logx.LoggerTypeZerolog:
+		return logx.NewZerologLoggerWithWriter(buf, logx.FormatJSON)
+	default:
+		return logx.NewSlogLoggerWithWriter(buf, logx.FormatJSON)
+	}
+}
@@ -0,0 +1,74 @@
+package logx_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	logx "github.com/vixyninja/go-blocks/logx"
+)
+
+func TestSampling_InitialThenEveryNth(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := logx.WithSampling(logx.NewStdLoggerWithWriter(&buf, ""), logx.SamplingConfig{
+		Initial:    2,
+		Thereafter: 3,
+		Tick:       time.Hour,
+	})
+
+	for i := 0; i < 10; i++ {
+		logger.Warn(ctx, "upstream slow %d", i)
+	}
+	logger.Info(ctx, "other key")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected 5 lines (4 sampled + 1 other key), got %d:\n%s", len(lines), buf.String())
+	}
+	for i, want := range []string{"upstream slow 0", "upstream slow 1", "upstream slow 4", "upstream slow 7", "other key"} {
+		if !strings.Contains(lines[i], want) {
+			t.Errorf("line %d: expected %q, got %q", i, want, lines[i])
+		}
+	}
+	if !strings.Contains(lines[2], "sampled_out=2") {
+		t.Errorf("expected dropped count on sampled entry, got %q", lines[2])
+	}
+	if logger.Dropped() != 6 {
+		t.Errorf("expected 6 dropped, got %d", logger.Dropped())
+	}
+}
+
+func TestSampling_ResetsEachTick(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := logx.WithSampling(logx.NewStdLoggerWithWriter(&buf, ""), logx.SamplingConfig{
+		Initial: 1,
+		Tick:    20 * time.Millisecond,
+	})
+	child := logger.With(ctx, map[string]any{"component": "sampler"})
+
+	child.Errorw(ctx, "flood")
+	child.Errorw(ctx, "flood")
+	time.Sleep(30 * time.Millisecond)
+	child.Errorw(ctx, "flood")
+
+	if n := strings.Count(buf.String(), "flood"); n != 2 {
+		t.Fatalf("expected 2 entries, got %d:\n%s", n, buf.String())
+	}
+	if logger.Dropped() != 1 {
+		t.Fatalf("expected 1 dropped, got %d", logger.Dropped())
+	}
+}
+
+func TestSampling_FromConfig(t *testing.T) {
+	lg := logx.NewLogger(logx.LoggerConfig{
+		Type:     logx.LoggerTypeLogrus,
+		Sampling: &logx.SamplingConfig{Initial: 1},
+	})
+	if _, ok := lg.(*logx.SampledLogger); !ok {
+		t.Fatalf("expected *logx.SampledLogger, got %T", lg)
+	}
+}
@@ -0,0 +1,82 @@
+package logx_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	logx "github.com/vixyninja/go-blocks/logx"
+)
+
+func TestSlogLogger_Basic(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := logx.NewSlogLogger(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	logger.With(ctx, map[string]any{"component": "slog"}).Warn(ctx, "hello %s", "world")
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	if entry["msg"] != "hello world" {
+		t.Errorf("expected msg 'hello world', got %v", entry["msg"])
+	}
+	if entry["level"] != "WARN" {
+		t.Errorf("expected level WARN, got %v", entry["level"])
+	}
+	if entry["component"] != "slog" {
+		t.Errorf("expected component field, got %v", entry["component"])
+	}
+
+	buf.Reset()
+	logger.SetLevel(logx.LevelError)
+	logger.Info(ctx, "filtered")
+	if buf.Len() != 0 {
+		t.Errorf("expected info to be filtered, got %q", buf.String())
+	}
+}
+
+func TestSlogHandler_WritesThroughLogx(t *testing.T) {
+	var buf bytes.Buffer
+	backend := logx.NewZerologLoggerWithWriter(&buf, logx.FormatJSON)
+	logger := slog.New(logx.NewSlogHandler(backend))
+
+	logger.With("service", "billing").WithGroup("http").Info("request done", "status", 200)
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	if entry["message"] != "request done" {
+		t.Errorf("expected message 'request done', got %v", entry["message"])
+	}
+	if entry["service"] != "billing" {
+		t.Errorf("expected service field, got %v", entry["service"])
+	}
+	if entry["http.status"] != float64(200) {
+		t.Errorf("expected grouped http.status field, got %v", entry["http.status"])
+	}
+}
+
+func TestSlogHandler_RespectsLevel(t *testing.T) {
+	var buf bytes.Buffer
+	backend := logx.NewZerologLoggerWithWriter(&buf, logx.FormatJSON)
+	backend.SetLevel(logx.LevelWarn)
+	logger := slog.New(logx.NewSlogHandler(backend))
+
+	logger.Debug("dropped")
+	logger.Info("dropped")
+	logger.Warn("kept")
+
+	out := buf.String()
+	if strings.Contains(out, "dropped") {
+		t.Errorf("expected debug/info to be dropped, got %q", out)
+	}
+	if !strings.Contains(out, "kept") {
+		t.Errorf("expected warn message, got %q", out)
+	}
+}
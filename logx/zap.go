@@ -3,17 +3,19 @@ package logx
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
-	"path/filepath"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 type ZapLogger struct {
 	logger *zap.Logger
 	fields map[string]any
+	level  zap.AtomicLevel
+	enrich EnrichConfig
 }
 
 func NewZapLogger() *ZapLogger {
@@ -21,37 +23,28 @@ func NewZapLogger() *ZapLogger {
 	config.EncoderConfig.TimeKey = TimeKey
 	config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 
-	logger, _ := config.Build()
-
-	return &ZapLogger{
-		logger: logger,
-		fields: make(map[string]any),
-	}
+	logger, _ := newZapLoggerFromConfig(config)
+	return logger
 }
 
 func NewZapLoggerWithConfig(config ZapConfig) *ZapLogger {
-	var logger *zap.Logger
-	var err error
+	var zapConfig zap.Config
 
 	if config.Production {
-		logger, err = zap.NewProduction()
+		zapConfig = zap.NewProductionConfig()
 	} else {
-		logger, err = zap.NewDevelopment()
-	}
-
-	if err != nil {
-		logger, _ = zap.NewDevelopment()
+		zapConfig = zap.NewDevelopmentConfig()
 	}
 
 	if config.Level != nil {
-		atomicLevel := zap.NewAtomicLevelAt(*config.Level)
-		logger = logger.WithOptions(zap.IncreaseLevel(atomicLevel))
+		zapConfig.Level.SetLevel(*config.Level)
 	}
 
-	return &ZapLogger{
-		logger: logger,
-		fields: make(map[string]any),
+	logger, err := newZapLoggerFromConfig(zapConfig)
+	if err != nil {
+		logger, _ = newZapLoggerFromConfig(zap.NewDevelopmentConfig())
 	}
+	return logger
 }
 
 func NewZapJSONLogger() *ZapLogger {
@@ -59,12 +52,8 @@ func NewZapJSONLogger() *ZapLogger {
 	config.EncoderConfig.TimeKey = TimeKey
 	config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 
-	logger, _ := config.Build()
-
-	return &ZapLogger{
-		logger: logger,
-		fields: make(map[string]any),
-	}
+	logger, _ := newZapLoggerFromConfig(config)
+	return logger
 }
 
 func NewZapConsoleLogger() *ZapLogger {
@@ -73,12 +62,28 @@ func NewZapConsoleLogger() *ZapLogger {
 	config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
 
-	logger, _ := config.Build()
+	logger, _ := newZapLoggerFromConfig(config)
+	return logger
+}
+
+// newZapLoggerFromConfig builds config with zap's own caller and stack trace
+// turned off, they would point inside this package. The logx enrichment
+// reports them from the real call site instead.
+func newZapLoggerFromConfig(config zap.Config) (*ZapLogger, error) {
+	config.DisableCaller = true
+	config.DisableStacktrace = true
+
+	logger, err := config.Build(zap.WithFatalHook(zapNoExit{}))
+	if err != nil {
+		return nil, err
+	}
 
 	return &ZapLogger{
 		logger: logger,
 		fields: make(map[string]any),
-	}
+		level:  config.Level,
+		enrich: EnrichConfig{Caller: true, StackTrace: true},
+	}, nil
 }
 
 func (l *ZapLogger) Debug(ctx context.Context, msg string, args ...any) {
@@ -98,10 +103,39 @@ func (l *ZapLogger) Error(ctx context.Context, msg string, args ...any) {
 }
 
 func (l *ZapLogger) Fatal(ctx context.Context, msg string, args ...any) {
-	l.log(ctx, zap.FatalLevel, msg, args...)
+	l.fatal(ctx, msg, args...)
 	os.Exit(1)
 }
 
+func (l *ZapLogger) Debugw(ctx context.Context, msg string, fields ...Field) {
+	l.write(ctx, zap.DebugLevel, msg, nil, fields)
+}
+
+func (l *ZapLogger) Infow(ctx context.Context, msg string, fields ...Field) {
+	l.write(ctx, zap.InfoLevel, msg, nil, fields)
+}
+
+func (l *ZapLogger) Warnw(ctx context.Context, msg string, fields ...Field) {
+	l.write(ctx, zap.WarnLevel, msg, nil, fields)
+}
+
+func (l *ZapLogger) Errorw(ctx context.Context, msg string, fields ...Field) {
+	l.write(ctx, zap.ErrorLevel, msg, nil, fields)
+}
+
+func (l *ZapLogger) Fatalw(ctx context.Context, msg string, fields ...Field) {
+	l.fatalw(ctx, msg, fields...)
+	os.Exit(1)
+}
+
+func (l *ZapLogger) fatal(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, zap.FatalLevel, msg, args...)
+}
+
+func (l *ZapLogger) fatalw(ctx context.Context, msg string, fields ...Field) {
+	l.write(ctx, zap.FatalLevel, msg, nil, fields)
+}
+
 func (l *ZapLogger) With(_ context.Context, fields map[string]any) Logx {
 	newFields := make(map[string]any)
 	for k, v := range l.fields {
@@ -114,28 +148,37 @@ func (l *ZapLogger) With(_ context.Context, fields map[string]any) Logx {
 	return &ZapLogger{
 		logger: l.logger,
 		fields: newFields,
+		level:  l.level,
+		enrich: l.enrich,
 	}
 }
 
+func (l *ZapLogger) SetEnrich(config EnrichConfig) {
+	l.enrich = config
+}
+
+func (l *ZapLogger) SetLevel(level LogLevel) {
+	l.level.SetLevel(toZapLevel(level))
+}
+
+func (l *ZapLogger) Level() LogLevel {
+	return fromZapLevel(l.level.Level())
+}
+
 func ZapLoggerWithRotation(filePath string, rotConfig *RotationConfig, format Format) (*ZapLogger, error) {
-	dir := filepath.Dir(filePath)
-	if err := os.MkdirAll(dir, 0700); err != nil {
-		return nil, fmt.Errorf("[pkg.logx.ZapLoggerWithRotation] failed to create log directory: %w", err)
+	lumberjackLogger, err := newRotationWriter(filePath, rotConfig)
+	if err != nil {
+		return nil, fmt.Errorf("[pkg.logx.ZapLoggerWithRotation] %w", err)
 	}
 
-	if rotConfig == nil {
-		rotConfig = DefaultRotationConfig()
-	}
+	return NewZapLoggerWithWriter(lumberjackLogger, format), nil
+}
 
-	lumberjackLogger := &lumberjack.Logger{
-		Filename:   filePath,
-		MaxSize:    rotConfig.MaxSize,
-		MaxBackups: rotConfig.MaxBackups,
-		MaxAge:     rotConfig.MaxAge,
-		Compress:   rotConfig.Compress,
-		LocalTime:  rotConfig.LocalTime,
-	}
+func NewZapLoggerWithWriter(w io.Writer, format Format) *ZapLogger {
+	return newZapLoggerWithWriter(w, format, false)
+}
 
+func newZapLoggerWithWriter(w io.Writer, format Format, color bool) *ZapLogger {
 	var encoder zapcore.Encoder
 	switch format {
 	case FormatJSON:
@@ -143,37 +186,55 @@ func ZapLoggerWithRotation(filePath string, rotConfig *RotationConfig, format Fo
 		encCfg.TimeKey = TimeKey
 		encCfg.EncodeTime = zapcore.ISO8601TimeEncoder
 		encoder = zapcore.NewJSONEncoder(encCfg)
-	case FormatText:
+	default:
 		encCfg := zap.NewDevelopmentEncoderConfig()
 		encCfg.TimeKey = TimeKey
 		encCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+		if color {
+			encCfg.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		}
 		encoder = zapcore.NewConsoleEncoder(encCfg)
 	}
 
+	level := zap.NewAtomicLevelAt(zap.DebugLevel)
 	core := zapcore.NewCore(
 		encoder,
-		zapcore.AddSync(lumberjackLogger),
-		zap.NewAtomicLevelAt(zap.DebugLevel),
+		zapcore.AddSync(w),
+		level,
 	)
 
 	return &ZapLogger{
-		logger: zap.New(core),
+		logger: zap.New(core, zap.WithFatalHook(zapNoExit{})),
 		fields: make(map[string]any),
-	}, nil
+		level:  level,
+		enrich: EnrichConfig{Caller: true, StackTrace: true},
+	}
 }
 
 func (l *ZapLogger) log(ctx context.Context, level zapcore.Level, msg string, args ...any) {
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+
+	l.write(ctx, level, msg, args, nil)
+}
+
+func (l *ZapLogger) write(ctx context.Context, level zapcore.Level, msg string, args []any, fields []Field) {
+	if !l.logger.Core().Enabled(level) {
+		return
+	}
+
+	extra := toZapFields(l.enrich.apply(fromZapLevel(level), args, fields))
+
 	zapFields := l.convertToZapFields(l.fields)
 
-	contextFields := extractContextFields(ctx)
+	contextFields := FieldsFromContext(ctx)
 	if len(contextFields) > 0 {
 		contextZapFields := l.convertToZapFields(contextFields)
 		zapFields = append(zapFields, contextZapFields...)
 	}
 
-	if len(args) > 0 {
-		msg = fmt.Sprintf(msg, args...)
-	}
+	zapFields = append(zapFields, extra...)
 
 	switch level {
 	case zap.DebugLevel:
@@ -201,11 +262,76 @@ func (l *ZapLogger) convertToZapFields(fields map[string]any) []zap.Field {
 	return zapFields
 }
 
+func toZapFields(fields []Field) []zap.Field {
+	zapFields := make([]zap.Field, 0, len(fields))
+	for _, f := range fields {
+		switch f.Type {
+		case FieldTypeString:
+			zapFields = append(zapFields, zap.String(f.Key, f.Str))
+		case FieldTypeInt64:
+			zapFields = append(zapFields, zap.Int64(f.Key, f.Integer))
+		case FieldTypeFloat64:
+			zapFields = append(zapFields, zap.Float64(f.Key, f.Float))
+		case FieldTypeBool:
+			zapFields = append(zapFields, zap.Bool(f.Key, f.Integer == 1))
+		case FieldTypeDuration:
+			zapFields = append(zapFields, zap.Duration(f.Key, time.Duration(f.Integer)))
+		case FieldTypeTime:
+			t, _ := f.Interface.(time.Time)
+			zapFields = append(zapFields, zap.Time(f.Key, t))
+		case FieldTypeError:
+			err, _ := f.Interface.(error)
+			zapFields = append(zapFields, zap.NamedError(f.Key, err))
+		default:
+			zapFields = append(zapFields, zap.Any(f.Key, f.Interface))
+		}
+	}
+	return zapFields
+}
+
 type ZapConfig struct {
 	Production bool
 	Level      *zapcore.Level
 }
 
+// zapNoExit stops zap from exiting on fatal entries, Fatal and Fatalw exit
+// themselves so wrappers such as MultiLogger can reach every sink first.
+type zapNoExit struct{}
+
+func (zapNoExit) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) {}
+
+func toZapLevel(level LogLevel) zapcore.Level {
+	switch level {
+	case LevelDebug:
+		return zap.DebugLevel
+	case LevelInfo:
+		return zap.InfoLevel
+	case LevelWarn:
+		return zap.WarnLevel
+	case LevelError:
+		return zap.ErrorLevel
+	case LevelFatal:
+		return zap.FatalLevel
+	default:
+		return zap.DebugLevel
+	}
+}
+
+func fromZapLevel(level zapcore.Level) LogLevel {
+	switch {
+	case level <= zap.DebugLevel:
+		return LevelDebug
+	case level == zap.InfoLevel:
+		return LevelInfo
+	case level == zap.WarnLevel:
+		return LevelWarn
+	case level == zap.ErrorLevel:
+		return LevelError
+	default:
+		return LevelFatal
+	}
+}
+
 func (l *ZapLogger) Sync() error {
 	return l.logger.Sync()
 }
@@ -5,17 +5,17 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
-	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 type ZerologLogger struct {
 	logger zerolog.Logger
 	fields map[string]any
+	level  *levelVar
+	enrich EnrichConfig
 }
 
 type ZerologConfig struct {
@@ -38,13 +38,12 @@ func NewZerologLoggerWithOutput(output io.Writer) *ZerologLogger {
 }
 
 func NewZerologLogger() *ZerologLogger {
-	zerolog.SetGlobalLevel(zerolog.DebugLevel)
-
 	logger := log.Output(zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339})
 
 	return &ZerologLogger{
 		logger: logger,
 		fields: make(map[string]any),
+		level:  newLevelVar(LevelDebug),
 	}
 }
 
@@ -57,19 +56,20 @@ func NewZerologLoggerWithConfig(config ZerologConfig) *ZerologLogger {
 		logger = log.Output(zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339})
 	}
 
+	// Filtering is done by the levelVar so the level can change at runtime
+	// without touching the process-wide zerolog.SetGlobalLevel.
+	level := LevelDebug
 	if config.Level != nil {
-		logger = logger.Level(*config.Level)
-	} else {
-		logger = logger.Level(zerolog.DebugLevel)
-	}
-
-	if config.Level != nil {
-		zerolog.SetGlobalLevel(*config.Level)
+		level = fromZerologLevel(*config.Level)
+		if *config.Level == zerolog.Disabled {
+			logger = logger.Level(zerolog.Disabled)
+		}
 	}
 
 	return &ZerologLogger{
 		logger: logger,
 		fields: make(map[string]any),
+		level:  newLevelVar(level),
 	}
 }
 
@@ -90,39 +90,31 @@ func NewZerologConsoleLogger() *ZerologLogger {
 }
 
 func ZerologLoggerWithRotation(filePath string, rotConfig *RotationConfig, format Format) (*ZerologLogger, error) {
-	dir := filepath.Dir(filePath)
-	if err := os.MkdirAll(dir, 0700); err != nil {
-		return nil, fmt.Errorf("[pkg.logx.ZerologLoggerWithRotation] failed to create log directory: %w", err)
+	lumberjackLogger, err := newRotationWriter(filePath, rotConfig)
+	if err != nil {
+		return nil, fmt.Errorf("[pkg.logx.ZerologLoggerWithRotation] %w", err)
 	}
 
-	if rotConfig == nil {
-		rotConfig = DefaultRotationConfig()
-	}
+	return NewZerologLoggerWithWriter(lumberjackLogger, format), nil
+}
 
-	lumberjackLogger := &lumberjack.Logger{
-		Filename:   filePath,
-		MaxSize:    rotConfig.MaxSize,
-		MaxBackups: rotConfig.MaxBackups,
-		MaxAge:     rotConfig.MaxAge,
-		Compress:   rotConfig.Compress,
-		LocalTime:  rotConfig.LocalTime,
-	}
+func NewZerologLoggerWithWriter(w io.Writer, format Format) *ZerologLogger {
+	return newZerologLoggerWithWriter(w, format, false)
+}
 
-	var out io.Writer = lumberjackLogger
-	switch format {
-	case FormatText:
-		out = zerolog.ConsoleWriter{Out: lumberjackLogger, TimeFormat: time.RFC3339, NoColor: true}
-	case FormatJSON:
-		out = lumberjackLogger
+func newZerologLoggerWithWriter(w io.Writer, format Format, color bool) *ZerologLogger {
+	out := w
+	if format != FormatJSON {
+		out = zerolog.ConsoleWriter{Out: w, TimeFormat: time.RFC3339, NoColor: !color}
 	}
 
 	logger := zerolog.New(out).With().Timestamp().Logger()
-	logger = logger.Level(zerolog.DebugLevel)
 
 	return &ZerologLogger{
 		logger: logger,
 		fields: make(map[string]any),
-	}, nil
+		level:  newLevelVar(LevelDebug),
+	}
 }
 
 func (l *ZerologLogger) Debug(ctx context.Context, msg string, args ...any) {
@@ -142,10 +134,39 @@ func (l *ZerologLogger) Error(ctx context.Context, msg string, args ...any) {
 }
 
 func (l *ZerologLogger) Fatal(ctx context.Context, msg string, args ...any) {
-	l.log(ctx, zerolog.FatalLevel, msg, args...)
+	l.fatal(ctx, msg, args...)
+	os.Exit(1)
+}
+
+func (l *ZerologLogger) Debugw(ctx context.Context, msg string, fields ...Field) {
+	l.logw(ctx, zerolog.DebugLevel, msg, fields)
+}
+
+func (l *ZerologLogger) Infow(ctx context.Context, msg string, fields ...Field) {
+	l.logw(ctx, zerolog.InfoLevel, msg, fields)
+}
+
+func (l *ZerologLogger) Warnw(ctx context.Context, msg string, fields ...Field) {
+	l.logw(ctx, zerolog.WarnLevel, msg, fields)
+}
+
+func (l *ZerologLogger) Errorw(ctx context.Context, msg string, fields ...Field) {
+	l.logw(ctx, zerolog.ErrorLevel, msg, fields)
+}
+
+func (l *ZerologLogger) Fatalw(ctx context.Context, msg string, fields ...Field) {
+	l.fatalw(ctx, msg, fields...)
 	os.Exit(1)
 }
 
+func (l *ZerologLogger) fatal(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, zerolog.FatalLevel, msg, args...)
+}
+
+func (l *ZerologLogger) fatalw(ctx context.Context, msg string, fields ...Field) {
+	l.logw(ctx, zerolog.FatalLevel, msg, fields)
+}
+
 func (l *ZerologLogger) With(_ context.Context, fields map[string]any) Logx {
 	newFields := make(map[string]any)
 	for k, v := range l.fields {
@@ -158,24 +179,105 @@ func (l *ZerologLogger) With(_ context.Context, fields map[string]any) Logx {
 	return &ZerologLogger{
 		logger: l.logger,
 		fields: newFields,
+		level:  l.level,
+		enrich: l.enrich,
 	}
 }
 
+func (l *ZerologLogger) SetEnrich(config EnrichConfig) {
+	l.enrich = config
+}
+
+func (l *ZerologLogger) SetLevel(level LogLevel) {
+	l.level.Set(level)
+}
+
+func (l *ZerologLogger) Level() LogLevel {
+	return l.level.Level()
+}
+
 func (l *ZerologLogger) log(ctx context.Context, level zerolog.Level, msg string, args ...any) {
+	event := l.event(ctx, level)
+	if event == nil {
+		return
+	}
+
+	event = addZerologFields(event, l.enrich.fields(fromZerologLevel(level), args, nil))
+
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+
+	event.Msg(msg)
+}
+
+func (l *ZerologLogger) logw(ctx context.Context, level zerolog.Level, msg string, fields []Field) {
+	event := l.event(ctx, level)
+	if event == nil {
+		return
+	}
+
+	event = addZerologFields(event, l.enrich.apply(fromZerologLevel(level), nil, fields))
+	event.Msg(msg)
+}
+
+func addZerologFields(event *zerolog.Event, fields []Field) *zerolog.Event {
+	for _, f := range fields {
+		switch f.Type {
+		case FieldTypeString:
+			event = event.Str(f.Key, f.Str)
+		case FieldTypeInt64:
+			event = event.Int64(f.Key, f.Integer)
+		case FieldTypeFloat64:
+			event = event.Float64(f.Key, f.Float)
+		case FieldTypeBool:
+			event = event.Bool(f.Key, f.Integer == 1)
+		case FieldTypeDuration:
+			event = event.Dur(f.Key, time.Duration(f.Integer))
+		case FieldTypeTime:
+			t, _ := f.Interface.(time.Time)
+			event = event.Time(f.Key, t)
+		case FieldTypeError:
+			err, _ := f.Interface.(error)
+			event = event.AnErr(f.Key, err)
+		default:
+			event = event.Interface(f.Key, f.Interface)
+		}
+	}
+	return event
+}
+
+// event starts an entry carrying the logger and context fields, nil when level is disabled.
+func (l *ZerologLogger) event(ctx context.Context, level zerolog.Level) *zerolog.Event {
+	if !l.level.Enabled(fromZerologLevel(level)) {
+		return nil
+	}
+
 	event := l.logger.WithLevel(level)
 
 	for k, v := range l.fields {
 		event = event.Interface(k, v)
 	}
 
-	contextFields := extractContextFields(ctx)
+	contextFields := FieldsFromContext(ctx)
 	for k, v := range contextFields {
 		event = event.Interface(k, v)
 	}
 
-	if len(args) > 0 {
-		msg = fmt.Sprintf(msg, args...)
-	}
+	return event
+}
 
-	event.Msg(msg)
+func fromZerologLevel(level zerolog.Level) LogLevel {
+	switch level {
+	case zerolog.TraceLevel, zerolog.DebugLevel:
+		return LevelDebug
+	case zerolog.InfoLevel:
+		return LevelInfo
+	case zerolog.WarnLevel:
+		return LevelWarn
+	case zerolog.ErrorLevel:
+		return LevelError
+	default:
+		return LevelFatal
+	}
 }
@@ -0,0 +1,229 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/vixyninja/go-blocks/jwt"
+	"github.com/vixyninja/go-blocks/logx"
+	"github.com/vixyninja/go-blocks/postgres"
+)
+
+// Error is a domain error that knows how it is sent to clients. Only Code,
+// Message and Details are sent; Cause is logged by FromError.
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+	Details any
+	Cause   error
+
+	level    logx.LogLevel
+	hasLevel bool
+}
+
+func NewError(status int, code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+// WrapError returns an Error with err as its cause.
+func WrapError(err error, status int, code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message, Cause: err}
+}
+
+func (e *Error) Error() string {
+	msg := e.Code + ": " + e.Message
+	if e.Cause != nil {
+		msg += ": " + e.Cause.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+func (e *Error) WithDetails(details any) *Error {
+	e.Details = details
+	return e
+}
+
+func (e *Error) WithCause(err error) *Error {
+	e.Cause = err
+	return e
+}
+
+// WithLevel sets the level FromError logs at. By default 5xx errors log at
+// error and others at info.
+func (e *Error) WithLevel(level logx.LogLevel) *Error {
+	e.level = level
+	e.hasLevel = true
+	return e
+}
+
+// LogLevel returns the level FromError logs e at.
+func (e *Error) LogLevel() logx.LogLevel {
+	if e.hasLevel {
+		return e.level
+	}
+	if e.Status >= http.StatusInternalServerError {
+		return logx.LevelError
+	}
+	return logx.LevelInfo
+}
+
+// ErrorMapper turns a known error into an Error, or reports false.
+type ErrorMapper func(err error) (*Error, bool)
+
+var (
+	mappersMu sync.RWMutex
+	mappers   = []ErrorMapper{mapJWTError, mapPostgresError, mapContextError, mapCursorError}
+)
+
+// RegisterErrorMapper adds mapper to the mappers FromError consults for
+// errors that are not an Error. Mappers registered later run first.
+func RegisterErrorMapper(mapper ErrorMapper) {
+	mappersMu.Lock()
+	defer mappersMu.Unlock()
+	mappers = append([]ErrorMapper{mapper}, mappers...)
+}
+
+type loggerHolder struct {
+	logger logx.Logx
+}
+
+var errorLogger atomic.Pointer[loggerHolder]
+
+// SetLogger sets the logger FromError and InternalServerError report causes
+// to. Without one, or after SetLogger(nil), they are logged to stderr.
+func SetLogger(logger logx.Logx) {
+	if logger == nil {
+		errorLogger.Store(nil)
+		return
+	}
+	errorLogger.Store(&loggerHolder{logger: logger})
+}
+
+// FromError sends err as an error response. An Error anywhere in the chain
+// is used as is, known errors are mapped by the registered ErrorMappers and
+// anything else is sent by InternalServerError. A nil err writes nothing.
+func FromError(w http.ResponseWriter, r *http.Request, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	appErr, ok := resolveError(err)
+	if !ok {
+		return InternalServerError(w, r, err)
+	}
+	logError(r, appErr, err)
+	return RespondError(w, r, appErr.Status, appErr.Code, appErr.Message, appErr.Details)
+}
+
+// resolveError finds the Error for err, or reports false for unknown errors.
+func resolveError(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return withValidStatus(appErr), true
+	}
+
+	mappersMu.RLock()
+	defer mappersMu.RUnlock()
+	for _, mapper := range mappers {
+		if mapped, ok := mapper(err); ok {
+			return withValidStatus(mapped), true
+		}
+	}
+	return nil, false
+}
+
+// withValidStatus returns e with a status outside 100-599 replaced by 500,
+// which net/http would otherwise panic on.
+func withValidStatus(e *Error) *Error {
+	if e.Status >= 100 && e.Status <= 599 {
+		return e
+	}
+	fixed := *e
+	fixed.Status = http.StatusInternalServerError
+	return &fixed
+}
+
+func logError(r *http.Request, appErr *Error, err error) {
+	var logger logx.Logx
+	if holder := errorLogger.Load(); holder != nil {
+		logger = holder.logger
+	} else {
+		// Never drop errors that production mode hides from the client.
+		logger = logx.NewStdLoggerWithWriter(os.Stderr, "")
+	}
+
+	ctx := context.Background()
+	fields := []logx.Field{
+		logx.Int("status", appErr.Status),
+		logx.String("code", appErr.Code),
+		logx.Err(err),
+	}
+	if r != nil {
+		ctx = r.Context()
+		fields = append(fields, logx.String("method", r.Method), logx.String("path", r.URL.Path))
+	}
+
+	switch appErr.LogLevel() {
+	case logx.LevelDebug:
+		logger.Debugw(ctx, "request failed", fields...)
+	case logx.LevelInfo:
+		logger.Infow(ctx, "request failed", fields...)
+	case logx.LevelWarn:
+		logger.Warnw(ctx, "request failed", fields...)
+	default:
+		logger.Errorw(ctx, "request failed", fields...)
+	}
+}
+
+func mapJWTError(err error) (*Error, bool) {
+	switch {
+	case errors.Is(err, jwt.ErrExpiredToken):
+		return WrapError(err, http.StatusUnauthorized, "token_expired", "Token has expired"), true
+	case errors.Is(err, jwt.ErrInvalidToken):
+		return WrapError(err, http.StatusUnauthorized, "invalid_token", "Invalid token"), true
+	}
+	return nil, false
+}
+
+// sqlStateError is implemented by the pgx and lib/pq error types.
+type sqlStateError interface {
+	SQLState() string
+}
+
+func mapPostgresError(err error) (*Error, bool) {
+	var pgErr sqlStateError
+	if !errors.As(err, &pgErr) {
+		return nil, false
+	}
+
+	switch pgErr.SQLState() {
+	case postgres.UniqueViolation, postgres.ExclusionViolation:
+		return WrapError(err, http.StatusConflict, "conflict", "Resource already exists"), true
+	case postgres.ForeignKeyViolation:
+		return WrapError(err, http.StatusConflict, "conflict", "Related resource is missing or still referenced"), true
+	}
+	return nil, false
+}
+
+func mapContextError(err error) (*Error, bool) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return WrapError(err, http.StatusGatewayTimeout, "timeout", "Request timed out"), true
+	}
+	return nil, false
+}
+
+func mapCursorError(err error) (*Error, bool) {
+	if errors.Is(err, ErrInvalidCursor) {
+		return WrapError(err, http.StatusBadRequest, "invalid_cursor", "Invalid cursor"), true
+	}
+	return nil, false
+}
@@ -0,0 +1,190 @@
+package response
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrInvalidCursor is returned for cursors that are malformed or were not signed by the codec.
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	// ErrCursorSecretTooShort is returned by NewCursorCodec for secrets under 32 bytes.
+	ErrCursorSecretTooShort = errors.New("cursor secret must be at least 32 bytes")
+)
+
+// Cursor is the position of a row in a keyset ordering. Values holds the
+// sort keys of that row in ORDER BY order; they keep their type through
+// encoding. Supported types are string, bool, the integer types (decoded as
+// int64 or uint64), float32 and float64 (decoded as float64), time.Time and nil.
+type Cursor struct {
+	Values []any
+	// Backward marks a cursor that pages towards the start, as in CursorMeta.PrevCursor.
+	Backward bool
+}
+
+// CursorCodec signs cursors with HMAC-SHA256 so clients can read but not forge them.
+type CursorCodec struct {
+	secret []byte
+}
+
+func NewCursorCodec(secret []byte) (*CursorCodec, error) {
+	if len(secret) < 32 {
+		return nil, ErrCursorSecretTooShort
+	}
+	return &CursorCodec{secret: append([]byte(nil), secret...)}, nil
+}
+
+type cursorPayload struct {
+	Backward bool        `json:"b,omitempty"`
+	Keys     [][2]string `json:"k"`
+}
+
+// Encode returns the opaque, URL-safe form of cursor.
+func (c *CursorCodec) Encode(cursor Cursor) (string, error) {
+	payload := cursorPayload{Backward: cursor.Backward, Keys: make([][2]string, len(cursor.Values))}
+	for i, v := range cursor.Values {
+		key, err := encodeCursorValue(v)
+		if err != nil {
+			return "", err
+		}
+		payload.Keys[i] = key
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(append(data, c.sign(data)...)), nil
+}
+
+func (c *CursorCodec) Decode(s string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(raw) <= sha256.Size {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	data, mac := raw[:len(raw)-sha256.Size], raw[len(raw)-sha256.Size:]
+	if !hmac.Equal(mac, c.sign(data)) {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	cursor := Cursor{Backward: payload.Backward, Values: make([]any, len(payload.Keys))}
+	for i, key := range payload.Keys {
+		v, err := decodeCursorValue(key)
+		if err != nil {
+			return Cursor{}, err
+		}
+		cursor.Values[i] = v
+	}
+	return cursor, nil
+}
+
+func (c *CursorCodec) sign(data []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func encodeCursorValue(v any) ([2]string, error) {
+	switch v := v.(type) {
+	case nil:
+		return [2]string{"n", ""}, nil
+	case string:
+		return [2]string{"s", v}, nil
+	case bool:
+		return [2]string{"b", strconv.FormatBool(v)}, nil
+	case int:
+		return [2]string{"i", strconv.FormatInt(int64(v), 10)}, nil
+	case int8:
+		return [2]string{"i", strconv.FormatInt(int64(v), 10)}, nil
+	case int16:
+		return [2]string{"i", strconv.FormatInt(int64(v), 10)}, nil
+	case int32:
+		return [2]string{"i", strconv.FormatInt(int64(v), 10)}, nil
+	case int64:
+		return [2]string{"i", strconv.FormatInt(v, 10)}, nil
+	case uint:
+		return [2]string{"u", strconv.FormatUint(uint64(v), 10)}, nil
+	case uint8:
+		return [2]string{"u", strconv.FormatUint(uint64(v), 10)}, nil
+	case uint16:
+		return [2]string{"u", strconv.FormatUint(uint64(v), 10)}, nil
+	case uint32:
+		return [2]string{"u", strconv.FormatUint(uint64(v), 10)}, nil
+	case uint64:
+		return [2]string{"u", strconv.FormatUint(v, 10)}, nil
+	case float32:
+		return [2]string{"f", strconv.FormatFloat(float64(v), 'g', -1, 32)}, nil
+	case float64:
+		return [2]string{"f", strconv.FormatFloat(v, 'g', -1, 64)}, nil
+	case time.Time:
+		return [2]string{"t", v.Format(time.RFC3339Nano)}, nil
+	default:
+		return [2]string{}, fmt.Errorf("unsupported cursor value type %T", v)
+	}
+}
+
+func decodeCursorValue(key [2]string) (any, error) {
+	var (
+		v   any
+		err error
+	)
+	switch key[0] {
+	case "n":
+		return nil, nil
+	case "s":
+		return key[1], nil
+	case "b":
+		v, err = strconv.ParseBool(key[1])
+	case "i":
+		v, err = strconv.ParseInt(key[1], 10, 64)
+	case "u":
+		v, err = strconv.ParseUint(key[1], 10, 64)
+	case "f":
+		v, err = strconv.ParseFloat(key[1], 64)
+	case "t":
+		v, err = time.Parse(time.RFC3339Nano, key[1])
+	default:
+		return nil, ErrInvalidCursor
+	}
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return v, nil
+}
+
+type CursorPagination struct {
+	Limit int
+	// Cursor is nil on the first page.
+	Cursor *Cursor
+}
+
+// ParseCursorPagination parses limit and cursor from query parameters. The
+// limit has the same defaults and bounds as ParsePagination. A cursor that
+// fails verification returns ErrInvalidCursor, which callers usually send
+// as a 400.
+func ParseCursorPagination(r *http.Request, codec *CursorCodec) (CursorPagination, error) {
+	p := CursorPagination{Limit: ParsePagination(r).Limit}
+
+	if v := r.URL.Query().Get("cursor"); v != "" {
+		cursor, err := codec.Decode(v)
+		if err != nil {
+			return p, err
+		}
+		p.Cursor = &cursor
+	}
+	return p, nil
+}
@@ -228,13 +228,14 @@ func UnavailableForLegalReasons(w http.ResponseWriter, r *http.Request, message
 	return RespondError(w, r, http.StatusUnavailableForLegalReasons, "unavailable_for_legal_reasons", message, nil)
 }
 
-// InternalServerError sends a 500 Internal Server Error response.
+// InternalServerError sends a 500 Internal Server Error response and logs
+// err. The client gets the error text only in DebugMode, see SetErrorMode.
 func InternalServerError(w http.ResponseWriter, r *http.Request, err error) error {
-	msg := "Internal server error"
 	if err != nil {
-		msg = err.Error()
+		logError(r, WrapError(err, http.StatusInternalServerError, "internal_error", internalErrorMessage), err)
 	}
-	return RespondError(w, r, http.StatusInternalServerError, "internal_error", msg, nil)
+	msg, details := internalError(r, err)
+	return RespondError(w, r, http.StatusInternalServerError, "internal_error", msg, details)
 }
 
 // NotImplemented sends a 501 Not Implemented response.
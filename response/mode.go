@@ -0,0 +1,73 @@
+package response
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync/atomic"
+
+	"github.com/vixyninja/go-blocks/logx"
+)
+
+// ErrorMode controls how much of an internal error reaches the client.
+type ErrorMode int32
+
+const (
+	// ProductionMode sends a generic message and the request ID. It is the default.
+	ProductionMode ErrorMode = iota
+	// DebugMode also sends the error text and a stack trace in details.
+	DebugMode
+)
+
+var errorMode atomic.Int32
+
+func SetErrorMode(mode ErrorMode) {
+	errorMode.Store(int32(mode))
+}
+
+func CurrentErrorMode() ErrorMode {
+	return ErrorMode(errorMode.Load())
+}
+
+const internalErrorMessage = "Internal server error"
+
+// internalError builds the message and details sent for a 500 caused by err.
+func internalError(r *http.Request, err error) (string, any) {
+	message := internalErrorMessage
+	details := map[string]any{}
+
+	if r != nil {
+		if id := logx.RequestIDFromContext(r.Context()); id != "" {
+			details["request_id"] = id
+		}
+	}
+	if CurrentErrorMode() == DebugMode && err != nil {
+		message = err.Error()
+		details["stack"] = stackTrace()
+	}
+
+	if len(details) == 0 {
+		return message, nil
+	}
+	return message, details
+}
+
+// stackTrace returns the caller's stack, without the frames of this package.
+func stackTrace() []string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var stack []string
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "github.com/vixyninja/go-blocks/response.") {
+			stack = append(stack, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+		}
+		if !more {
+			break
+		}
+	}
+	return stack
+}
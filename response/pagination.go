@@ -1,47 +1,137 @@
 package response
 
 import (
+	"fmt"
+	"math"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 )
 
 type Pagination struct {
 	Limit  int
 	Offset int
+	Page   int // 1-based page holding Offset
+}
+
+// PaginationConfig bounds the limit accepted by ParsePaginationWithConfig.
+type PaginationConfig struct {
+	DefaultLimit int // limit when none is given (default: 20)
+	MaxLimit     int // larger limits are capped (default: 100)
+}
+
+func DefaultPaginationConfig() PaginationConfig {
+	return PaginationConfig{DefaultLimit: 20, MaxLimit: 100}
+}
+
+func (c PaginationConfig) withDefaults() PaginationConfig {
+	defaults := DefaultPaginationConfig()
+	if c.MaxLimit <= 0 {
+		c.MaxLimit = defaults.MaxLimit
+	}
+	if c.DefaultLimit <= 0 {
+		c.DefaultLimit = defaults.DefaultLimit
+	}
+	c.DefaultLimit = min(c.DefaultLimit, c.MaxLimit)
+	return c
 }
 
 // ParsePagination parses limit and offset from query parameters with sane defaults and bounds.
 // - limit default: 20, min: 1, max: 100
 // - offset default: 0, min: 0
+// See ParsePaginationWithConfig for page-style parameters.
 func ParsePagination(r *http.Request) Pagination {
-	const (
-		defaultLimit = 20
-		maxLimit     = 100
-	)
+	return ParsePaginationWithConfig(r, DefaultPaginationConfig())
+}
 
-	limit := defaultLimit
-	if v := r.URL.Query().Get("limit"); v != "" {
+// ParsePaginationWithConfig parses either limit and offset or page and
+// per_page from query parameters. page is 1-based and wins over offset;
+// per_page is an alias of limit. Invalid values fall back to the defaults.
+func ParsePaginationWithConfig(r *http.Request, config PaginationConfig) Pagination {
+	config = config.withDefaults()
+	q := r.URL.Query()
+
+	limit := config.DefaultLimit
+	v := q.Get("per_page")
+	if v == "" {
+		v = q.Get("limit")
+	}
+	if v != "" {
 		if n, err := strconv.Atoi(v); err == nil {
-			if n < 1 {
-				limit = 1
-			} else if n > maxLimit {
-				limit = maxLimit
-			} else {
-				limit = n
-			}
+			limit = min(max(n, 1), config.MaxLimit)
 		}
 	}
 
+	// Clamp huge offsets so offset+limit, as used for the next page, cannot overflow.
+	maxOffset := math.MaxInt - limit
 	offset := 0
-	if v := r.URL.Query().Get("offset"); v != "" {
+	if v := q.Get("page"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 1 {
+			offset = min(n-1, maxOffset/limit) * limit
+		}
+	} else if v := q.Get("offset"); v != "" {
 		if n, err := strconv.Atoi(v); err == nil {
-			if n < 0 {
-				offset = 0
-			} else {
-				offset = n
-			}
+			offset = min(max(n, 0), maxOffset)
 		}
 	}
 
-	return Pagination{Limit: limit, Offset: offset}
+	return Pagination{Limit: limit, Offset: offset, Page: offset/limit + 1}
+}
+
+// NewPageMeta builds the meta for a page of count items out of total.
+func NewPageMeta(p Pagination, count, total int) PageMeta {
+	meta := PageMeta{
+		Limit:   p.Limit,
+		Offset:  p.Offset,
+		Count:   count,
+		Page:    p.Page,
+		Total:   total,
+		HasNext: p.Offset+count < total,
+	}
+	if p.Limit > 0 {
+		meta.TotalPages = (total + p.Limit - 1) / p.Limit
+	}
+	return meta
+}
+
+// pageLinks returns an RFC 8288 Link header value with first, prev, next
+// and last links. Links reuse the request URL and keep the pagination style
+// of the request, page and per_page or limit and offset.
+func pageLinks(r *http.Request, meta PageMeta) string {
+	if meta.Limit <= 0 {
+		return ""
+	}
+
+	query := r.URL.Query()
+	pageStyle := query.Has("page") || query.Has("per_page")
+
+	link := func(offset int, rel string) string {
+		q := r.URL.Query()
+		if pageStyle {
+			q.Del("limit")
+			q.Del("offset")
+			q.Set("page", strconv.Itoa(offset/meta.Limit+1))
+			q.Set("per_page", strconv.Itoa(meta.Limit))
+		} else {
+			q.Del("page")
+			q.Del("per_page")
+			q.Set("limit", strconv.Itoa(meta.Limit))
+			q.Set("offset", strconv.Itoa(offset))
+		}
+		u := url.URL{Path: r.URL.Path, RawPath: r.URL.RawPath, RawQuery: q.Encode()}
+		return fmt.Sprintf("<%s>; rel=%q", u.String(), rel)
+	}
+
+	links := []string{link(0, "first")}
+	if meta.Offset > 0 {
+		links = append(links, link(max(meta.Offset-meta.Limit, 0), "prev"))
+	}
+	if meta.HasNext {
+		links = append(links, link(meta.Offset+meta.Limit, "next"))
+	}
+	if meta.TotalPages > 0 {
+		links = append(links, link((meta.TotalPages-1)*meta.Limit, "last"))
+	}
+	return strings.Join(links, ", ")
 }
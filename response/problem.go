@@ -0,0 +1,190 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// ProblemContentType is the media type of RFC 9457 problem details.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 9457 problem details object. Extensions are written as
+// top-level members next to the standard ones.
+type Problem struct {
+	Type       string         `json:"type,omitempty"`
+	Title      string         `json:"title,omitempty"`
+	Status     int            `json:"status,omitempty"`
+	Detail     string         `json:"detail,omitempty"`
+	Instance   string         `json:"instance,omitempty"`
+	Extensions map[string]any `json:"-"`
+}
+
+func (p Problem) MarshalJSON() ([]byte, error) {
+	members := make(map[string]any, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		members[k] = v
+	}
+	set := func(key string, value any, empty bool) {
+		if !empty {
+			members[key] = value
+		}
+	}
+	set("type", p.Type, p.Type == "")
+	set("title", p.Title, p.Title == "")
+	set("status", p.Status, p.Status == 0)
+	set("detail", p.Detail, p.Detail == "")
+	set("instance", p.Instance, p.Instance == "")
+	return json.Marshal(members)
+}
+
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	type standard Problem
+	if err := json.Unmarshal(data, (*standard)(p)); err != nil {
+		return err
+	}
+
+	var members map[string]any
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	for _, k := range []string{"type", "title", "status", "detail", "instance"} {
+		delete(members, k)
+	}
+	if len(members) > 0 {
+		p.Extensions = members
+	}
+	return nil
+}
+
+// ErrorRenderer writes error responses for RespondError and the helpers in errors.go.
+type ErrorRenderer interface {
+	RenderError(w http.ResponseWriter, r *http.Request, status int, body ErrorResponse) error
+}
+
+type ErrorRendererFunc func(w http.ResponseWriter, r *http.Request, status int, body ErrorResponse) error
+
+func (f ErrorRendererFunc) RenderError(w http.ResponseWriter, r *http.Request, status int, body ErrorResponse) error {
+	return f(w, r, status, body)
+}
+
+// JSONErrorRenderer writes ErrorResponse as application/json. It is the default.
+var JSONErrorRenderer ErrorRenderer = ErrorRendererFunc(func(w http.ResponseWriter, r *http.Request, status int, body ErrorResponse) error {
+	return writeJSON(w, status, body)
+})
+
+// ProblemRenderer writes errors as application/problem+json. The error code
+// and details become the "code" and "details" extension members.
+type ProblemRenderer struct {
+	// TypeBase is prefixed to the error code to form the problem type, for
+	// example "https://errors.example.com/". Empty uses "about:blank".
+	TypeBase string
+}
+
+func (p ProblemRenderer) RenderError(w http.ResponseWriter, r *http.Request, status int, body ErrorResponse) error {
+	problem := Problem{
+		Type:       "about:blank",
+		Title:      http.StatusText(status),
+		Status:     status,
+		Detail:     body.Message,
+		Extensions: map[string]any{"code": body.Code},
+	}
+	if p.TypeBase != "" && body.Code != "" {
+		problem.Type = p.TypeBase + body.Code
+	}
+	if r != nil && r.URL != nil {
+		problem.Instance = r.URL.Path
+	}
+	if body.Details != nil {
+		problem.Extensions["details"] = body.Details
+	}
+	return RespondProblem(w, r, problem)
+}
+
+// RespondProblem sends problem with its status, or 500 when it has none.
+func RespondProblem(w http.ResponseWriter, r *http.Request, problem Problem) error {
+	status := problem.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(problem)
+}
+
+// Negotiate uses problem when the request accepts application/problem+json
+// and fallback otherwise.
+func Negotiate(problem, fallback ErrorRenderer) ErrorRenderer {
+	return ErrorRendererFunc(func(w http.ResponseWriter, r *http.Request, status int, body ErrorResponse) error {
+		if r != nil && acceptsProblem(r.Header.Get("Accept")) {
+			return problem.RenderError(w, r, status, body)
+		}
+		return fallback.RenderError(w, r, status, body)
+	})
+}
+
+func acceptsProblem(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || mediaType != ProblemContentType {
+			continue
+		}
+		q, ok := params["q"]
+		if !ok {
+			return true
+		}
+		// q=0, q=0.0 and the like refuse the type.
+		weight, err := strconv.ParseFloat(q, 64)
+		return err == nil && weight > 0
+	}
+	return false
+}
+
+type rendererHolder struct {
+	renderer ErrorRenderer
+}
+
+var defaultRenderer atomic.Pointer[rendererHolder]
+
+// SetErrorRenderer changes the renderer used when the request carries none.
+// A nil renderer restores JSONErrorRenderer.
+func SetErrorRenderer(renderer ErrorRenderer) {
+	if renderer == nil {
+		defaultRenderer.Store(nil)
+		return
+	}
+	defaultRenderer.Store(&rendererHolder{renderer: renderer})
+}
+
+type rendererKey struct{}
+
+// ContextWithErrorRenderer returns a copy of ctx whose requests render errors with renderer.
+func ContextWithErrorRenderer(ctx context.Context, renderer ErrorRenderer) context.Context {
+	return context.WithValue(ctx, rendererKey{}, renderer)
+}
+
+// WithErrorRenderer is a middleware that sets the error renderer for the
+// requests it handles, for example one chi router group.
+func WithErrorRenderer(renderer ErrorRenderer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(ContextWithErrorRenderer(r.Context(), renderer)))
+		})
+	}
+}
+
+func errorRenderer(r *http.Request) ErrorRenderer {
+	if r != nil {
+		if renderer, ok := r.Context().Value(rendererKey{}).(ErrorRenderer); ok && renderer != nil {
+			return renderer
+		}
+	}
+	if holder := defaultRenderer.Load(); holder != nil {
+		return holder.renderer
+	}
+	return JSONErrorRenderer
+}
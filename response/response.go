@@ -3,6 +3,7 @@ package response
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 )
 
 // writeJSON writes a JSON response with the given status code.
@@ -33,14 +34,56 @@ func RespondNoContent(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+// PageOption adds headers to RespondPaged.
+type PageOption func(*pageOptions)
+
+type pageOptions struct {
+	link       bool
+	totalCount bool
+}
+
+// WithLinkHeader adds an RFC 8288 Link header with first, prev, next and
+// last links built from the request URL.
+func WithLinkHeader() PageOption {
+	return func(o *pageOptions) {
+		o.link = true
+	}
+}
+
+// WithTotalCountHeader adds an X-Total-Count header with meta.Total.
+func WithTotalCountHeader() PageOption {
+	return func(o *pageOptions) {
+		o.totalCount = true
+	}
+}
+
 // RespondPaged sends a 200 OK response with paginated data.
-func RespondPaged[T any](w http.ResponseWriter, r *http.Request, data T, meta PageMeta) error {
+func RespondPaged[T any](w http.ResponseWriter, r *http.Request, data T, meta PageMeta, opts ...PageOption) error {
+	var o pageOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.link {
+		if link := pageLinks(r, meta); link != "" {
+			w.Header().Set("Link", link)
+		}
+	}
+	if o.totalCount {
+		w.Header().Set("X-Total-Count", strconv.Itoa(meta.Total))
+	}
 	return writeJSON(w, http.StatusOK, PageResponse[T]{Data: data, Meta: meta})
 }
 
-// RespondError sends an error response with the given status code and error details.
+// RespondCursor sends a 200 OK response with cursor paginated data.
+func RespondCursor[T any](w http.ResponseWriter, r *http.Request, data T, meta CursorMeta) error {
+	return writeJSON(w, http.StatusOK, CursorResponse[T]{Data: data, Meta: meta})
+}
+
+// RespondError sends an error response with the given status code and error
+// details, in the shape chosen by the request's ErrorRenderer.
 func RespondError(w http.ResponseWriter, r *http.Request, status int, code, message string, details any) error {
-	return writeJSON(w, status, ErrorResponse{
+	return errorRenderer(r).RenderError(w, r, status, ErrorResponse{
 		Code:    code,
 		Message: message,
 		Details: details,
@@ -0,0 +1,149 @@
+package response_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vixyninja/go-blocks/jwt"
+	"github.com/vixyninja/go-blocks/logx"
+	"github.com/vixyninja/go-blocks/logx/logxtest"
+	"github.com/vixyninja/go-blocks/response"
+)
+
+// pgError mimics the SQLState method of pgconn.PgError and pq.Error.
+type pgError struct {
+	code string
+}
+
+func (e *pgError) Error() string {
+	return "duplicate key value violates unique constraint \"users_email_key\""
+}
+
+func (e *pgError) SQLState() string {
+	return e.code
+}
+
+func fromError(t *testing.T, err error) (*httptest.ResponseRecorder, response.ErrorResponse) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/users", nil)
+	if err := response.FromError(w, r, err); err != nil {
+		t.Fatalf("FromError() error = %v", err)
+	}
+
+	var body response.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	return w, body
+}
+
+func TestFromError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{
+			"wrapped domain error",
+			fmt.Errorf("create user: %w", response.NewError(http.StatusUnprocessableEntity, "invalid_email", "Email is invalid")),
+			http.StatusUnprocessableEntity,
+			"invalid_email",
+		},
+		{"expired token", fmt.Errorf("auth: %w", jwt.ErrExpiredToken), http.StatusUnauthorized, "token_expired"},
+		{"invalid token", jwt.ErrInvalidToken, http.StatusUnauthorized, "invalid_token"},
+		{"unique violation", fmt.Errorf("insert: %w", &pgError{code: "23505"}), http.StatusConflict, "conflict"},
+		{"deadline", context.DeadlineExceeded, http.StatusGatewayTimeout, "timeout"},
+		{"unknown", errors.New("dial tcp 10.0.0.5:5432: connection refused"), http.StatusInternalServerError, "internal_error"},
+		{"missing status", &response.Error{Code: "oops"}, http.StatusInternalServerError, "oops"},
+		{"invalid status", response.NewError(1000, "oops", "Out of range"), http.StatusInternalServerError, "oops"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w, body := fromError(t, test.err)
+			if w.Code != test.status {
+				t.Errorf("expected status %d, got %d", test.status, w.Code)
+			}
+			if body.Code != test.code {
+				t.Errorf("expected code %q, got %q", test.code, body.Code)
+			}
+		})
+	}
+}
+
+func TestFromError_HidesCause(t *testing.T) {
+	rec := logxtest.NewRecorder()
+	response.SetLogger(rec)
+	defer response.SetLogger(nil)
+
+	cause := errors.New("dial tcp 10.0.0.5:5432: connection refused")
+	w, body := fromError(t, fmt.Errorf("load user: %w", cause))
+
+	if strings.Contains(w.Body.String(), "10.0.0.5") {
+		t.Errorf("expected the cause to stay out of the response, got %s", w.Body.String())
+	}
+	if body.Message != "Internal server error" {
+		t.Errorf("expected a generic message, got %q", body.Message)
+	}
+
+	entries := rec.FilterLevel(logx.LevelError)
+	if len(entries) != 1 {
+		t.Fatalf("expected one error entry, got %d", len(entries))
+	}
+	if logged, _ := entries[0].Field("error"); !strings.Contains(fmt.Sprint(logged), "10.0.0.5") {
+		t.Errorf("expected the cause to be logged, got %v", entries[0])
+	}
+}
+
+func TestFromError_LogLevel(t *testing.T) {
+	rec := logxtest.NewRecorder()
+	response.SetLogger(rec)
+	defer response.SetLogger(nil)
+
+	fromError(t, response.NewError(http.StatusNotFound, "not_found", "User not found"))
+	fromError(t, response.NewError(http.StatusConflict, "conflict", "Taken").WithLevel(logx.LevelWarn))
+
+	if len(rec.FilterLevel(logx.LevelInfo)) != 1 || len(rec.FilterLevel(logx.LevelWarn)) != 1 {
+		t.Errorf("expected one info and one warn entry, got %v", rec.Entries())
+	}
+}
+
+func TestError_Details(t *testing.T) {
+	cause := errors.New("boom")
+	err := response.WrapError(cause, http.StatusBadRequest, "bad_request", "Invalid request").
+		WithDetails(map[string]string{"name": "required"})
+
+	if !errors.Is(err, cause) {
+		t.Error("expected Error to unwrap to its cause")
+	}
+
+	_, body := fromError(t, err)
+	details, ok := body.Details.(map[string]any)
+	if !ok || details["name"] != "required" {
+		t.Errorf("expected details in the response, got %v", body.Details)
+	}
+}
+
+func TestRegisterErrorMapper(t *testing.T) {
+	errQuota := errors.New("quota exceeded")
+	response.RegisterErrorMapper(func(err error) (*response.Error, bool) {
+		if errors.Is(err, errQuota) {
+			return response.WrapError(err, http.StatusTooManyRequests, "quota_exceeded", "Quota exceeded"), true
+		}
+		return nil, false
+	})
+
+	w, body := fromError(t, errQuota)
+	if w.Code != http.StatusTooManyRequests || body.Code != "quota_exceeded" {
+		t.Errorf("expected the registered mapping, got %d %q", w.Code, body.Code)
+	}
+}
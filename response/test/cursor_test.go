@@ -0,0 +1,142 @@
+package response_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vixyninja/go-blocks/response"
+)
+
+func newCursorCodec(t *testing.T) *response.CursorCodec {
+	t.Helper()
+
+	codec, err := response.NewCursorCodec([]byte(strings.Repeat("k", 32)))
+	if err != nil {
+		t.Fatalf("NewCursorCodec() error = %v", err)
+	}
+	return codec
+}
+
+func TestCursorCodec_RoundTrip(t *testing.T) {
+	codec := newCursorCodec(t)
+	created := time.Date(2024, 3, 1, 12, 30, 0, 123456789, time.UTC)
+
+	encoded, err := codec.Encode(response.Cursor{Values: []any{created, 42, uint64(7), 1.5, "a&b", true, nil}, Backward: true})
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	cursor, err := codec.Decode(encoded)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !cursor.Backward {
+		t.Error("expected the direction to be kept")
+	}
+
+	want := []any{created, int64(42), uint64(7), 1.5, "a&b", true, nil}
+	if len(cursor.Values) != len(want) {
+		t.Fatalf("expected %d values, got %v", len(want), cursor.Values)
+	}
+	for i, v := range cursor.Values {
+		if tv, ok := v.(time.Time); ok {
+			if !tv.Equal(created) {
+				t.Errorf("value %d: expected %v, got %v", i, created, tv)
+			}
+			continue
+		}
+		if v != want[i] {
+			t.Errorf("value %d: expected %#v, got %#v", i, want[i], v)
+		}
+	}
+}
+
+func TestCursorCodec_RejectsForgery(t *testing.T) {
+	codec := newCursorCodec(t)
+	encoded, _ := codec.Encode(response.Cursor{Values: []any{int64(100)}})
+
+	other, _ := response.NewCursorCodec([]byte(strings.Repeat("x", 32)))
+	if _, err := other.Decode(encoded); !errors.Is(err, response.ErrInvalidCursor) {
+		t.Errorf("expected a cursor from another key to be rejected, got %v", err)
+	}
+
+	tampered := []byte(encoded)
+	tampered[3] ^= 1
+	for _, s := range []string{string(tampered), "not-base64!", "", "YWJj"} {
+		if _, err := codec.Decode(s); !errors.Is(err, response.ErrInvalidCursor) {
+			t.Errorf("expected ErrInvalidCursor for %q, got %v", s, err)
+		}
+	}
+}
+
+func TestCursorCodec_Errors(t *testing.T) {
+	if _, err := response.NewCursorCodec([]byte("short")); !errors.Is(err, response.ErrCursorSecretTooShort) {
+		t.Errorf("expected ErrCursorSecretTooShort, got %v", err)
+	}
+	if _, err := newCursorCodec(t).Encode(response.Cursor{Values: []any{struct{}{}}}); err == nil {
+		t.Error("expected an error for an unsupported value type")
+	}
+}
+
+func TestParseCursorPagination(t *testing.T) {
+	codec := newCursorCodec(t)
+
+	p, err := response.ParseCursorPagination(httptest.NewRequest(http.MethodGet, "/items?limit=500", nil), codec)
+	if err != nil {
+		t.Fatalf("ParseCursorPagination() error = %v", err)
+	}
+	if p.Limit != 100 || p.Cursor != nil {
+		t.Errorf("expected a capped limit and no cursor on the first page, got %+v", p)
+	}
+
+	encoded, _ := codec.Encode(response.Cursor{Values: []any{"b", int64(9)}})
+	p, err = response.ParseCursorPagination(httptest.NewRequest(http.MethodGet, "/items?limit=5&cursor="+encoded, nil), codec)
+	if err != nil {
+		t.Fatalf("ParseCursorPagination() error = %v", err)
+	}
+	if p.Limit != 5 || p.Cursor == nil || p.Cursor.Values[1] != int64(9) {
+		t.Errorf("unexpected pagination %+v", p)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/items?cursor=forged", nil)
+	_, err = response.ParseCursorPagination(r, codec)
+	if !errors.Is(err, response.ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	_ = response.FromError(w, r, err)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected FromError to send a 400, got %d", w.Code)
+	}
+}
+
+func TestRespondCursor(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	err := response.RespondCursor(w, r, []string{"a", "b"}, response.CursorMeta{NextCursor: "next", HasMore: true})
+	if err != nil {
+		t.Fatalf("RespondCursor() error = %v", err)
+	}
+
+	var body struct {
+		Data []string       `json:"data"`
+		Meta map[string]any `json:"meta"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	meta := body.Meta
+	if meta["next_cursor"] != "next" || meta["has_more"] != true {
+		t.Errorf("unexpected meta %v", meta)
+	}
+	if _, ok := meta["prev_cursor"]; ok {
+		t.Errorf("expected an empty prev_cursor to be omitted, got %v", meta)
+	}
+}
@@ -0,0 +1,102 @@
+package response_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/vixyninja/go-blocks/logx"
+	"github.com/vixyninja/go-blocks/logx/logxtest"
+	"github.com/vixyninja/go-blocks/response"
+)
+
+func internalServerError(t *testing.T, err error) response.ErrorResponse {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r = r.WithContext(logx.ContextWithRequestID(r.Context(), "req-123"))
+
+	if err := response.InternalServerError(w, r, err); err != nil {
+		t.Fatalf("InternalServerError() error = %v", err)
+	}
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+
+	var body response.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	return body
+}
+
+func TestInternalServerError_ProductionMode(t *testing.T) {
+	rec := logxtest.NewRecorder()
+	response.SetLogger(rec)
+	defer response.SetLogger(nil)
+
+	body := internalServerError(t, errors.New(`pq: relation "users" does not exist`))
+
+	if body.Message != "Internal server error" {
+		t.Errorf("expected a generic message, got %q", body.Message)
+	}
+	details, _ := body.Details.(map[string]any)
+	if details["request_id"] != "req-123" {
+		t.Errorf("expected the request ID in details, got %v", body.Details)
+	}
+	if _, ok := details["stack"]; ok {
+		t.Error("expected no stack trace in production mode")
+	}
+
+	rec.AssertLogged(t, logx.LevelError, "request failed")
+	if logged, _ := rec.Entries()[0].Field("error"); !strings.Contains(fmt.Sprint(logged), "relation") {
+		t.Errorf("expected the full error to be logged, got %v", logged)
+	}
+}
+
+func TestInternalServerError_LogsToStderrByDefault(t *testing.T) {
+	stderr := os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+	os.Stderr = w
+	defer func() { os.Stderr = stderr }()
+
+	body := internalServerError(t, errors.New("pq: secret sql"))
+	w.Close()
+	logged, _ := io.ReadAll(r)
+
+	if body.Message != "Internal server error" {
+		t.Errorf("expected a generic message, got %q", body.Message)
+	}
+	if !strings.Contains(string(logged), "pq: secret sql") {
+		t.Errorf("expected the error on stderr without SetLogger, got %q", logged)
+	}
+}
+
+func TestInternalServerError_DebugMode(t *testing.T) {
+	response.SetErrorMode(response.DebugMode)
+	defer response.SetErrorMode(response.ProductionMode)
+
+	body := internalServerError(t, errors.New("disk full"))
+
+	if body.Message != "disk full" {
+		t.Errorf("expected the error text in debug mode, got %q", body.Message)
+	}
+	details, _ := body.Details.(map[string]any)
+	stack, _ := details["stack"].([]any)
+	if len(stack) == 0 {
+		t.Fatalf("expected a stack trace in details, got %v", body.Details)
+	}
+	if first, _ := stack[0].(string); !strings.Contains(first, "TestInternalServerError_DebugMode") && !strings.Contains(first, "internalServerError") {
+		t.Errorf("expected the stack to start at the caller, got %v", first)
+	}
+}
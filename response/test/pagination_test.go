@@ -0,0 +1,122 @@
+package response_test
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vixyninja/go-blocks/response"
+)
+
+func TestParsePagination(t *testing.T) {
+	tests := []struct {
+		query    string
+		config   response.PaginationConfig
+		expected response.Pagination
+	}{
+		{"", response.DefaultPaginationConfig(), response.Pagination{Limit: 20, Offset: 0, Page: 1}},
+		{"limit=500&offset=-3", response.DefaultPaginationConfig(), response.Pagination{Limit: 100, Offset: 0, Page: 1}},
+		{"limit=10&offset=30", response.DefaultPaginationConfig(), response.Pagination{Limit: 10, Offset: 30, Page: 4}},
+		{"page=3&per_page=25", response.DefaultPaginationConfig(), response.Pagination{Limit: 25, Offset: 50, Page: 3}},
+		{"page=0&offset=40", response.DefaultPaginationConfig(), response.Pagination{Limit: 20, Offset: 0, Page: 1}},
+		{"page=abc", response.DefaultPaginationConfig(), response.Pagination{Limit: 20, Offset: 0, Page: 1}},
+		{"", response.PaginationConfig{DefaultLimit: 50, MaxLimit: 30}, response.Pagination{Limit: 30, Offset: 0, Page: 1}},
+		{"per_page=300", response.PaginationConfig{MaxLimit: 250}, response.Pagination{Limit: 250, Offset: 0, Page: 1}},
+		{"page=92233720368547760&per_page=100", response.DefaultPaginationConfig(), response.Pagination{Limit: 100, Offset: (math.MaxInt - 100) / 100 * 100, Page: (math.MaxInt-100)/100 + 1}},
+		{"offset=9223372036854775807&limit=10", response.DefaultPaginationConfig(), response.Pagination{Limit: 10, Offset: math.MaxInt - 10, Page: (math.MaxInt-10)/10 + 1}},
+	}
+
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/items?"+test.query, nil)
+		if got := response.ParsePaginationWithConfig(r, test.config); got != test.expected {
+			t.Errorf("%q: expected %+v, got %+v", test.query, test.expected, got)
+		}
+	}
+}
+
+func TestNewPageMeta(t *testing.T) {
+	meta := response.NewPageMeta(response.Pagination{Limit: 10, Offset: 20, Page: 3}, 10, 45)
+	if meta.Total != 45 || meta.TotalPages != 5 || !meta.HasNext || meta.Page != 3 {
+		t.Errorf("unexpected meta %+v", meta)
+	}
+
+	meta = response.NewPageMeta(response.Pagination{Limit: 10, Offset: 40, Page: 5}, 5, 45)
+	if meta.HasNext {
+		t.Errorf("expected no next page on the last page, got %+v", meta)
+	}
+}
+
+func TestRespondPaged_Headers(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/items?page=2&per_page=10&sort=name", nil)
+
+	p := response.ParsePagination(r)
+	meta := response.NewPageMeta(p, 10, 35)
+	err := response.RespondPaged(w, r, []int{}, meta, response.WithLinkHeader(), response.WithTotalCountHeader())
+	if err != nil {
+		t.Fatalf("RespondPaged() error = %v", err)
+	}
+
+	expected := `</items?page=1&per_page=10&sort=name>; rel="first", ` +
+		`</items?page=1&per_page=10&sort=name>; rel="prev", ` +
+		`</items?page=3&per_page=10&sort=name>; rel="next", ` +
+		`</items?page=4&per_page=10&sort=name>; rel="last"`
+	if got := w.Header().Get("Link"); got != expected {
+		t.Errorf("expected Link\n%s\ngot\n%s", expected, got)
+	}
+	if got := w.Header().Get("X-Total-Count"); got != "35" {
+		t.Errorf("expected X-Total-Count 35, got %q", got)
+	}
+
+	var body response.PageResponse[[]int]
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if body.Meta.TotalPages != 4 || !body.Meta.HasNext {
+		t.Errorf("unexpected meta %+v", body.Meta)
+	}
+}
+
+func TestRespondPaged_OffsetLinks(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/items?limit=20", nil)
+
+	meta := response.NewPageMeta(response.ParsePagination(r), 20, 30)
+	_ = response.RespondPaged(w, r, []int{}, meta, response.WithLinkHeader())
+
+	expected := `</items?limit=20&offset=0>; rel="first", ` +
+		`</items?limit=20&offset=20>; rel="next", ` +
+		`</items?limit=20&offset=20>; rel="last"`
+	if got := w.Header().Get("Link"); got != expected {
+		t.Errorf("expected Link\n%s\ngot\n%s", expected, got)
+	}
+}
+
+func TestRespondPaged_HugeOffset(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/items?offset=9223372036854775807&limit=10", nil)
+
+	p := response.ParsePagination(r)
+	meta := response.NewPageMeta(p, 0, 100)
+	_ = response.RespondPaged(w, r, []int{}, meta, response.WithLinkHeader())
+
+	if meta.HasNext {
+		t.Errorf("expected no next page past the total, got %+v", meta)
+	}
+	if link := w.Header().Get("Link"); strings.Contains(link, "offset=-") {
+		t.Errorf("expected no negative offsets in links, got %s", link)
+	}
+}
+
+func TestRespondPaged_NoHeadersByDefault(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/items", nil)
+
+	_ = response.RespondPaged(w, r, []int{}, response.PageMeta{Limit: 20})
+	if w.Header().Get("Link") != "" || w.Header().Get("X-Total-Count") != "" {
+		t.Errorf("expected no pagination headers, got %v", w.Header())
+	}
+}
@@ -0,0 +1,125 @@
+package response_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vixyninja/go-blocks/response"
+)
+
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) response.Problem {
+	t.Helper()
+
+	if ct := w.Header().Get("Content-Type"); ct != response.ProblemContentType {
+		t.Fatalf("expected content type %q, got %q", response.ProblemContentType, ct)
+	}
+	var problem response.Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("failed to unmarshal problem: %v", err)
+	}
+	return problem
+}
+
+func TestProblemRenderer(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	r = r.WithContext(response.ContextWithErrorRenderer(r.Context(), response.ProblemRenderer{TypeBase: "https://errors.example.com/"}))
+
+	if err := response.NotFound(w, r, "user not found"); err != nil {
+		t.Fatalf("NotFound() error = %v", err)
+	}
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	problem := decodeProblem(t, w)
+	if problem.Type != "https://errors.example.com/not_found" {
+		t.Errorf("expected type from code, got %q", problem.Type)
+	}
+	if problem.Title != "Not Found" || problem.Status != http.StatusNotFound {
+		t.Errorf("expected title and status of a 404, got %q %d", problem.Title, problem.Status)
+	}
+	if problem.Detail != "user not found" || problem.Instance != "/users/42" {
+		t.Errorf("unexpected detail %q or instance %q", problem.Detail, problem.Instance)
+	}
+	if problem.Extensions["code"] != "not_found" {
+		t.Errorf("expected code extension, got %v", problem.Extensions)
+	}
+}
+
+func TestProblemRenderer_Details(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r = r.WithContext(response.ContextWithErrorRenderer(r.Context(), response.ProblemRenderer{}))
+
+	_ = response.BadRequest(w, r, map[string]string{"email": "required"})
+
+	problem := decodeProblem(t, w)
+	if problem.Type != "about:blank" {
+		t.Errorf("expected about:blank without a type base, got %q", problem.Type)
+	}
+	details, ok := problem.Extensions["details"].(map[string]any)
+	if !ok || details["email"] != "required" {
+		t.Errorf("expected details extension, got %v", problem.Extensions)
+	}
+}
+
+func TestWithErrorRenderer(t *testing.T) {
+	handler := response.WithErrorRenderer(response.ProblemRenderer{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = response.Forbidden(w, r, "")
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if problem := decodeProblem(t, w); problem.Status != http.StatusForbidden {
+		t.Errorf("expected status %d, got %d", http.StatusForbidden, problem.Status)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	renderer := response.Negotiate(response.ProblemRenderer{}, response.JSONErrorRenderer)
+
+	tests := []struct {
+		accept      string
+		contentType string
+	}{
+		{"", "application/json"},
+		{"application/json", "application/json"},
+		{"application/problem+json", response.ProblemContentType},
+		{"application/json;q=0.9, application/problem+json", response.ProblemContentType},
+		{"application/problem+json;q=0", "application/json"},
+		{"application/problem+json;q=0.000", "application/json"},
+		{"application/problem+json;q=0.001", response.ProblemContentType},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept", test.accept)
+		r = r.WithContext(response.ContextWithErrorRenderer(r.Context(), renderer))
+
+		_ = response.Conflict(w, r, "")
+		if ct := w.Header().Get("Content-Type"); ct != test.contentType {
+			t.Errorf("Accept %q: expected content type %q, got %q", test.accept, test.contentType, ct)
+		}
+	}
+}
+
+func TestSetErrorRenderer(t *testing.T) {
+	response.SetErrorRenderer(response.ProblemRenderer{})
+	defer response.SetErrorRenderer(nil)
+
+	w := httptest.NewRecorder()
+	_ = response.ServiceUnavailable(w, httptest.NewRequest(http.MethodGet, "/", nil), "")
+	decodeProblem(t, w)
+
+	response.SetErrorRenderer(nil)
+	w = httptest.NewRecorder()
+	_ = response.ServiceUnavailable(w, httptest.NewRequest(http.MethodGet, "/", nil), "")
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected the default renderer back, got %q", ct)
+	}
+}
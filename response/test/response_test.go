@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/vixyninja/go-blocks/response"
@@ -98,6 +99,10 @@ func TestRespondPaged(t *testing.T) {
 	if resp.Meta.Count != 3 {
 		t.Fatalf("expected count 3, got %d", resp.Meta.Count)
 	}
+
+	if strings.Contains(w.Body.String(), "has_next") || strings.Contains(w.Body.String(), "total") {
+		t.Errorf("expected unset totals to be omitted, got %s", w.Body.String())
+	}
 }
 
 func TestRespondError(t *testing.T) {
@@ -7,11 +7,17 @@ type Response[T any] struct {
 	Data T `json:"data"`
 }
 
-// PageMeta contains pagination metadata.
+// PageMeta contains pagination metadata, see NewPageMeta. Total, TotalPages
+// and HasNext are omitted when unset, so a meta without a known total does
+// not claim there is no next page.
 type PageMeta struct {
-	Limit  int `json:"limit"`
-	Offset int `json:"offset"`
-	Count  int `json:"count"`
+	Limit      int  `json:"limit"`
+	Offset     int  `json:"offset"`
+	Count      int  `json:"count"`
+	Page       int  `json:"page,omitempty"`
+	Total      int  `json:"total,omitempty"`
+	TotalPages int  `json:"total_pages,omitempty"`
+	HasNext    bool `json:"has_next,omitempty"`
 }
 
 // PageResponse represents a paginated API response.
@@ -20,6 +26,20 @@ type PageResponse[T any] struct {
 	Meta PageMeta `json:"meta"`
 }
 
+// CursorMeta contains cursor pagination metadata. Cursors are empty when
+// there is no page in that direction.
+type CursorMeta struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// CursorResponse represents a cursor paginated API response.
+type CursorResponse[T any] struct {
+	Data T          `json:"data"`
+	Meta CursorMeta `json:"meta"`
+}
+
 // ErrorResponse represents an error API response.
 type ErrorResponse struct {
 	Code    string `json:"code"`